@@ -5,6 +5,7 @@ go 1.24.4
 tool github.com/bcomnes/goversion/v2
 
 require (
+	github.com/fsnotify/fsnotify v1.10.1
 	github.com/tdewolff/parse/v2 v2.8.15
 	github.com/xeipuuv/gojsonschema v1.2.0
 )
@@ -14,4 +15,5 @@ require (
 	github.com/xeipuuv/gojsonpointer v0.0.0-20180127040702-4e3ac2762d5f // indirect
 	github.com/xeipuuv/gojsonreference v0.0.0-20180127040603-bd5ef7bd5415 // indirect
 	golang.org/x/mod v0.25.0 // indirect
+	golang.org/x/sys v0.13.0 // indirect
 )
@@ -0,0 +1,33 @@
+package colormath
+
+// cubeLevels are the channel values of the xterm 6×6×6 color cube
+var cubeLevels = [6]float64{0, 95, 135, 175, 215, 255}
+
+// ANSI256Color returns the color xterm uses for a 256-color palette index
+// from 16 to 255. The first 16 are left to the terminal's own scheme, so
+// they return black.
+func ANSI256Color(i int) Color {
+	switch {
+	case i >= 16 && i < 232:
+		i -= 16
+		return Color{R: cubeLevels[i/36] / 255, G: cubeLevels[i/6%6] / 255, B: cubeLevels[i%6] / 255, A: 1}
+	case i >= 232 && i < 256:
+		v := float64(8+10*(i-232)) / 255
+		return Color{R: v, G: v, B: v, A: 1}
+	}
+	return Color{A: 1}
+}
+
+// ANSI256 returns the xterm 256-color palette index closest to c by
+// CIEDE2000, searching the color cube and the gray ramp (16..255). Alpha is
+// ignored; composite translucent colors first.
+func ANSI256(c Color) int {
+	c = c.Opaque()
+	best, bestD := 16, -1.0
+	for i := 16; i < 256; i++ {
+		if d := DeltaE(c, ANSI256Color(i)); bestD < 0 || d < bestD {
+			best, bestD = i, d
+		}
+	}
+	return best
+}
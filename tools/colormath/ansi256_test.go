@@ -0,0 +1,24 @@
+package colormath
+
+import "testing"
+
+func TestANSI256(t *testing.T) {
+	cases := map[string]int{
+		"#000000": 16,
+		"#ffffff": 231,
+		"#ff0000": 196,
+		"#5f87af": 67,
+		"#080808": 232,
+		"#eeeeee": 255,
+	}
+	for hex, want := range cases {
+		if got := ANSI256(MustParseHex(hex)); got != want {
+			t.Errorf("ANSI256(%s) = %d, want %d", hex, got, want)
+		}
+	}
+	for i := 16; i < 256; i++ {
+		if got := ANSI256(ANSI256Color(i)); ANSI256Color(got) != ANSI256Color(i) {
+			t.Errorf("palette color %d quantizes to %d", i, got)
+		}
+	}
+}
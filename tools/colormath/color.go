@@ -0,0 +1,151 @@
+package colormath
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Color is a straight (non-premultiplied) sRGB color with alpha.
+// All components are in the range 0..1.
+type Color struct {
+	R, G, B, A float64
+}
+
+// ParseHex parses #rgb, #rgba, #rrggbb and #rrggbbaa color strings
+func ParseHex(s string) (Color, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(s), "#")
+
+	switch len(hex) {
+	case 3, 4:
+		var expanded strings.Builder
+		for _, r := range hex {
+			expanded.WriteRune(r)
+			expanded.WriteRune(r)
+		}
+		hex = expanded.String()
+	}
+
+	switch len(hex) {
+	case 6:
+		hex += "ff"
+	case 8:
+	default:
+		return Color{}, fmt.Errorf("invalid hex color %q", s)
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return Color{}, fmt.Errorf("invalid hex color %q: %w", s, err)
+	}
+
+	return Color{
+		R: float64(v>>24&0xff) / 255,
+		G: float64(v>>16&0xff) / 255,
+		B: float64(v>>8&0xff) / 255,
+		A: float64(v&0xff) / 255,
+	}, nil
+}
+
+// MustParseHex parses a hex color string, panics if it is invalid
+func MustParseHex(s string) Color {
+	c, err := ParseHex(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Hex formats the color as a lowercase 8-digit #rrggbbaa string
+func (c Color) Hex() string {
+	r, g, b, a := c.bytes()
+	return fmt.Sprintf("#%02x%02x%02x%02x", r, g, b, a)
+}
+
+// NRGBA converts the color to an image/color value
+func (c Color) NRGBA() color.NRGBA {
+	r, g, b, a := c.bytes()
+	return color.NRGBA{R: r, G: g, B: b, A: a}
+}
+
+// FromNRGBA converts an image/color value to a Color
+func FromNRGBA(c color.NRGBA) Color {
+	return Color{
+		R: float64(c.R) / 255,
+		G: float64(c.G) / 255,
+		B: float64(c.B) / 255,
+		A: float64(c.A) / 255,
+	}
+}
+
+// Opaque returns the color with its alpha forced to 1
+func (c Color) Opaque() Color {
+	c.A = 1
+	return c
+}
+
+// WithAlpha returns the color with the given alpha
+func (c Color) WithAlpha(a float64) Color {
+	c.A = clamp01(a)
+	return c
+}
+
+// IsOpaque reports whether the color has full alpha once quantized to 8 bits
+func (c Color) IsOpaque() bool {
+	_, _, _, a := c.bytes()
+	return a == 0xff
+}
+
+// Over composites c on top of bg using source-over blending in sRGB space,
+// which matches how Zed blends translucent theme colors.
+func (c Color) Over(bg Color) Color {
+	a := c.A + bg.A*(1-c.A)
+	if a == 0 {
+		return Color{}
+	}
+	blend := func(fg, back float64) float64 {
+		return (fg*c.A + back*bg.A*(1-c.A)) / a
+	}
+	return Color{
+		R: blend(c.R, bg.R),
+		G: blend(c.G, bg.G),
+		B: blend(c.B, bg.B),
+		A: a,
+	}
+}
+
+// bytes quantizes the color to 8-bit channels
+func (c Color) bytes() (r, g, b, a uint8) {
+	q := func(v float64) uint8 {
+		return uint8(math.Round(clamp01(v) * 255))
+	}
+	return q(c.R), q(c.G), q(c.B), q(c.A)
+}
+
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+// linear converts an sRGB channel to linear light
+func linear(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// Luminance returns the WCAG relative luminance of the opaque color
+func (c Color) Luminance() float64 {
+	return 0.2126*linear(c.R) + 0.7152*linear(c.G) + 0.0722*linear(c.B)
+}
+
+// Contrast returns the WCAG 2 contrast ratio between two opaque colors
+func Contrast(a, b Color) float64 {
+	la, lb := a.Luminance(), b.Luminance()
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
@@ -0,0 +1,54 @@
+package colormath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDichromacySimulate(t *testing.T) {
+	// Grays have no chromatic signal to lose, and alpha is carried through
+	for _, hex := range []string{"#000000ff", "#808080ff", "#ffffff80"} {
+		c := MustParseHex(hex)
+		for i, m := range Dichromacies {
+			if got := m.Simulate(c).Hex(); got != c.Hex() {
+				t.Errorf("Dichromacies[%d].Simulate(%s) = %s, want %s", i, hex, got, c.Hex())
+			}
+		}
+	}
+
+	// Red and green collapse for red-green dichromats, blue and yellow
+	// hold up; tritanopes see the opposite
+	red, green := MustParseHex("#d04040"), MustParseHex("#40a040")
+	blue, yellow := MustParseHex("#4060d0"), MustParseHex("#d0c040")
+	for _, tc := range []struct {
+		name     string
+		m        Dichromacy
+		redGreen bool
+	}{
+		{"protanopia", Protanopia, true},
+		{"deuteranopia", Deuteranopia, true},
+		{"tritanopia", Tritanopia, false},
+	} {
+		rg := DeltaE(tc.m.Simulate(red), tc.m.Simulate(green)) / DeltaE(red, green)
+		by := DeltaE(tc.m.Simulate(blue), tc.m.Simulate(yellow)) / DeltaE(blue, yellow)
+		if (rg < by) != tc.redGreen {
+			t.Errorf("%s: red/green keeps %.0f%% of its ΔE, blue/yellow %.0f%%", tc.name, rg*100, by*100)
+		}
+	}
+}
+
+func TestWorstDeltaE(t *testing.T) {
+	red, green := MustParseHex("#d04040"), MustParseHex("#40a040")
+	worst := WorstDeltaE(red, green)
+	if worst > DeltaE(red, green) {
+		t.Errorf("WorstDeltaE = %.2f exceeds normal vision ΔE %.2f", worst, DeltaE(red, green))
+	}
+	for i, m := range Dichromacies {
+		if d := DeltaE(m.Simulate(red), m.Simulate(green)); worst > d+1e-9 {
+			t.Errorf("WorstDeltaE = %.2f exceeds Dichromacies[%d] ΔE %.2f", worst, i, d)
+		}
+	}
+	if d := WorstDeltaE(red, red); math.Abs(d) > 1e-9 {
+		t.Errorf("WorstDeltaE of a color with itself = %.4f, want 0", d)
+	}
+}
@@ -0,0 +1,35 @@
+package colormath
+
+import "math"
+
+// HSL is a color in the sRGB HSL model: H in degrees 0..360, S and L 0..1
+type HSL struct {
+	H, S, L float64
+}
+
+// HSL converts the color to HSL, ignoring alpha
+func (c Color) HSL() HSL {
+	maxC := math.Max(c.R, math.Max(c.G, c.B))
+	minC := math.Min(c.R, math.Min(c.G, c.B))
+	l := (maxC + minC) / 2
+	d := maxC - minC
+	if d == 0 {
+		return HSL{L: l}
+	}
+
+	s := d / (1 - math.Abs(2*l-1))
+	var h float64
+	switch maxC {
+	case c.R:
+		h = math.Mod((c.G-c.B)/d, 6)
+	case c.G:
+		h = (c.B-c.R)/d + 2
+	default:
+		h = (c.R-c.G)/d + 4
+	}
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+	return HSL{H: h, S: s, L: l}
+}
@@ -0,0 +1,24 @@
+package colormath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHSL(t *testing.T) {
+	cases := []struct {
+		hex  string
+		want HSL
+	}{
+		{"#ffffff", HSL{L: 1}},
+		{"#ff0000", HSL{H: 0, S: 1, L: 0.5}},
+		{"#00ff00", HSL{H: 120, S: 1, L: 0.5}},
+		{"#6ee2ff", HSL{H: 192, S: 1, L: 0.7157}},
+	}
+	for _, tc := range cases {
+		got := MustParseHex(tc.hex).HSL()
+		if math.Abs(got.H-tc.want.H) > 0.1 || math.Abs(got.S-tc.want.S) > 1e-3 || math.Abs(got.L-tc.want.L) > 1e-3 {
+			t.Errorf("%s.HSL() = %+v, want %+v", tc.hex, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package colormath
+
+import "math"
+
+// Lab is a color in CIE L*a*b* (D65 white point)
+type Lab struct {
+	L, A, B float64
+}
+
+// fromLinear converts a linear-light channel back to sRGB
+func fromLinear(v float64) float64 {
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
+
+// Linear returns the color's linear-light RGB channels
+func (c Color) Linear() (r, g, b float64) {
+	return linear(c.R), linear(c.G), linear(c.B)
+}
+
+// FromLinear builds an opaque color from linear-light RGB, clamping out of
+// gamut values
+func FromLinear(r, g, b float64) Color {
+	return Color{
+		R: clamp01(fromLinear(clamp01(r))),
+		G: clamp01(fromLinear(clamp01(g))),
+		B: clamp01(fromLinear(clamp01(b))),
+		A: 1,
+	}
+}
+
+// Lab converts the opaque color to CIE L*a*b*
+func (c Color) Lab() Lab {
+	r, g, b := c.Linear()
+	x := (0.4124564*r + 0.3575761*g + 0.1804375*b) / 0.95047
+	y := 0.2126729*r + 0.7151522*g + 0.0721750*b
+	z := (0.0193339*r + 0.1191920*g + 0.9503041*b) / 1.08883
+
+	f := func(t float64) float64 {
+		if t > 216.0/24389 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27*t + 16) / 116
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return Lab{L: 116*fy - 16, A: 500 * (fx - fy), B: 200 * (fy - fz)}
+}
+
+// DeltaE returns the CIEDE2000 color difference between two opaque colors.
+// Differences below about 1 are imperceptible; above 10 are clearly distinct.
+func DeltaE(c1, c2 Color) float64 {
+	return DeltaE2000(c1.Lab(), c2.Lab())
+}
+
+// DeltaE2000 implements the CIEDE2000 formula (Sharma, Wu & Dalal 2005)
+func DeltaE2000(x, y Lab) float64 {
+	rad := math.Pi / 180
+	c1 := math.Hypot(x.A, x.B)
+	c2 := math.Hypot(y.A, y.B)
+	cBar := (c1 + c2) / 2
+	g := 0.5 * (1 - math.Sqrt(math.Pow(cBar, 7)/(math.Pow(cBar, 7)+math.Pow(25, 7))))
+
+	a1 := (1 + g) * x.A
+	a2 := (1 + g) * y.A
+	c1p := math.Hypot(a1, x.B)
+	c2p := math.Hypot(a2, y.B)
+
+	hue := func(b, a float64) float64 {
+		if a == 0 && b == 0 {
+			return 0
+		}
+		h := math.Atan2(b, a) / rad
+		if h < 0 {
+			h += 360
+		}
+		return h
+	}
+	h1 := hue(x.B, a1)
+	h2 := hue(y.B, a2)
+
+	dL := y.L - x.L
+	dC := c2p - c1p
+	var dh float64
+	switch {
+	case c1p*c2p == 0:
+		dh = 0
+	case math.Abs(h2-h1) <= 180:
+		dh = h2 - h1
+	case h2-h1 > 180:
+		dh = h2 - h1 - 360
+	default:
+		dh = h2 - h1 + 360
+	}
+	dH := 2 * math.Sqrt(c1p*c2p) * math.Sin(dh/2*rad)
+
+	lBar := (x.L + y.L) / 2
+	cBarP := (c1p + c2p) / 2
+	var hBar float64
+	switch {
+	case c1p*c2p == 0:
+		hBar = h1 + h2
+	case math.Abs(h1-h2) <= 180:
+		hBar = (h1 + h2) / 2
+	case h1+h2 < 360:
+		hBar = (h1 + h2 + 360) / 2
+	default:
+		hBar = (h1 + h2 - 360) / 2
+	}
+
+	t := 1 - 0.17*math.Cos((hBar-30)*rad) + 0.24*math.Cos(2*hBar*rad) +
+		0.32*math.Cos((3*hBar+6)*rad) - 0.20*math.Cos((4*hBar-63)*rad)
+	dTheta := 30 * math.Exp(-math.Pow((hBar-275)/25, 2))
+	rc := 2 * math.Sqrt(math.Pow(cBarP, 7)/(math.Pow(cBarP, 7)+math.Pow(25, 7)))
+	sl := 1 + 0.015*math.Pow(lBar-50, 2)/math.Sqrt(20+math.Pow(lBar-50, 2))
+	sc := 1 + 0.045*cBarP
+	sh := 1 + 0.015*cBarP*t
+	rt := -math.Sin(2*dTheta*rad) * rc
+
+	return math.Sqrt(math.Pow(dL/sl, 2) + math.Pow(dC/sc, 2) + math.Pow(dH/sh, 2) + rt*(dC/sc)*(dH/sh))
+}
@@ -0,0 +1,70 @@
+package colormath
+
+import (
+	"math"
+	"testing"
+)
+
+// Reference pairs from Sharma, Wu & Dalal, "The CIEDE2000 Color-Difference Formula"
+func TestDeltaE2000(t *testing.T) {
+	cases := []struct {
+		a, b Lab
+		want float64
+	}{
+		{Lab{50, 2.6772, -79.7751}, Lab{50, 0, -82.7485}, 2.0425},
+		{Lab{50, -1.3802, -84.2814}, Lab{50, 0, -82.7485}, 1.0000},
+		{Lab{50, 2.5, 0}, Lab{73, 25, -18}, 27.1492},
+		{Lab{60.2574, -34.0099, 36.2677}, Lab{60.4626, -34.1751, 39.4387}, 1.2644},
+		{Lab{22.7233, 20.0904, -46.6940}, Lab{23.0331, 14.9730, -42.5619}, 2.0373},
+		{Lab{2.0776, 0.0795, -1.1350}, Lab{0.9033, -0.0636, -0.5514}, 0.9082},
+	}
+	for _, tc := range cases {
+		if got := DeltaE2000(tc.a, tc.b); math.Abs(got-tc.want) > 1e-4 {
+			t.Errorf("DeltaE2000(%v, %v) = %.4f, want %.4f", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestContrast(t *testing.T) {
+	black := MustParseHex("#000000")
+	white := MustParseHex("#ffffff")
+	if got := Contrast(black, white); math.Abs(got-21) > 1e-9 {
+		t.Errorf("Contrast(black, white) = %v, want 21", got)
+	}
+}
+
+func TestHexRoundTrip(t *testing.T) {
+	for _, hex := range []string{"#14191fcc", "#6ee2ff1a", "#ffffffff", "#00000000"} {
+		if got := MustParseHex(hex).Hex(); got != hex {
+			t.Errorf("MustParseHex(%q).Hex() = %q", hex, got)
+		}
+	}
+}
+
+func TestOKLCH(t *testing.T) {
+	// Reference values from the CSS Color 4 specification
+	cases := []struct {
+		hex  string
+		want OKLCH
+	}{
+		{"#ffffff", OKLCH{L: 1, C: 0}},
+		{"#ff0000", OKLCH{L: 0.62796, C: 0.25768, H: 29.2339}},
+		{"#0000ff", OKLCH{L: 0.45201, C: 0.31321, H: 264.052}},
+	}
+	for _, tc := range cases {
+		got := MustParseHex(tc.hex).OKLCH()
+		if math.Abs(got.L-tc.want.L) > 1e-3 || math.Abs(got.C-tc.want.C) > 1e-3 ||
+			(tc.want.C > 0 && math.Abs(got.H-tc.want.H) > 0.1) {
+			t.Errorf("%s.OKLCH() = %+v, want %+v", tc.hex, got, tc.want)
+		}
+		if back := got.Color(1).Hex(); back != MustParseHex(tc.hex).Hex() {
+			t.Errorf("%s round trips to %s", tc.hex, back)
+		}
+	}
+
+	// Out of gamut colors are mapped by reducing chroma
+	c := OKLCH{L: 0.7, C: 0.4, H: 150}.Color(1)
+	if got := c.OKLCH(); math.Abs(got.L-0.7) > 0.01 || math.Abs(got.H-150) > 1 {
+		t.Errorf("gamut mapping changed lightness or hue: %+v", got)
+	}
+}
@@ -0,0 +1,120 @@
+package colormath
+
+import "math"
+
+// OKLCH is a color in the polar form of Björn Ottosson's OKLab space.
+// L is perceived lightness (0..1), C chroma (0..~0.37) and H hue in degrees.
+// Equal steps in L look equally spaced, which makes it the space of choice
+// for deriving palettes.
+type OKLCH struct {
+	L, C, H float64
+}
+
+// OKLCH converts the opaque color to OKLCH
+func (c Color) OKLCH() OKLCH {
+	r, g, b := c.Linear()
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	L := 0.2104542553*l + 0.7936177850*m - 0.0040720468*s
+	A := 1.9779984951*l - 2.4285922050*m + 0.4505937099*s
+	B := 0.0259040371*l + 0.7827717662*m - 0.8086757660*s
+
+	h := math.Atan2(B, A) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return OKLCH{L: L, C: math.Hypot(A, B), H: h}
+}
+
+// linearRGB converts to linear sRGB without clamping
+func (o OKLCH) linearRGB() (r, g, b float64) {
+	a := o.C * math.Cos(o.H*math.Pi/180)
+	bb := o.C * math.Sin(o.H*math.Pi/180)
+
+	l := o.L + 0.3963377774*a + 0.2158037573*bb
+	m := o.L - 0.1055613458*a - 0.0638541728*bb
+	s := o.L - 0.0894841775*a - 1.2914855480*bb
+	l, m, s = l*l*l, m*m*m, s*s*s
+
+	return 4.0767416621*l - 3.3077115913*m + 0.2309699292*s,
+		-1.2684380046*l + 2.6097574011*m - 0.3413193965*s,
+		-0.0041960863*l - 0.7034186147*m + 1.7076147010*s
+}
+
+// InGamut reports whether the color can be shown in sRGB without clipping
+func (o OKLCH) InGamut() bool {
+	const eps = 1e-6
+	r, g, b := o.linearRGB()
+	return r >= -eps && r <= 1+eps && g >= -eps && g <= 1+eps && b >= -eps && b <= 1+eps
+}
+
+// Color converts back to sRGB with the given alpha. Out of gamut colors
+// keep their lightness and hue and lose chroma until they fit, the same
+// gamut mapping CSS Color 4 uses.
+func (o OKLCH) Color(alpha float64) Color {
+	o.L = clamp01(o.L)
+	o.C = math.Max(0, o.C)
+	if !o.InGamut() {
+		lo, hi := 0.0, o.C
+		for i := 0; i < 24; i++ {
+			o.C = (lo + hi) / 2
+			if o.InGamut() {
+				lo = o.C
+			} else {
+				hi = o.C
+			}
+		}
+		o.C = lo
+	}
+	r, g, b := o.linearRGB()
+	c := FromLinear(r, g, b)
+	c.A = clamp01(alpha)
+	return c
+}
+
+// Map converts c to OKLCH, applies fn and converts back, keeping alpha
+func (c Color) Map(fn func(OKLCH) OKLCH) Color {
+	return fn(c.OKLCH()).Color(c.A)
+}
+
+// EnsureContrast returns fg with its OKLCH lightness pushed away from bg
+// just far enough to reach the WCAG contrast ratio. Hue is kept; chroma is
+// reduced only where the lighter or darker color leaves the sRGB gamut. If
+// the ratio is out of reach the most extreme lightness is returned.
+func EnsureContrast(fg, bg Color, ratio float64) Color {
+	meets := func(c Color) bool {
+		// Compare the quantized color so the result holds once written as hex
+		return Contrast(MustParseHex(c.Hex()), bg) >= ratio
+	}
+	if meets(fg) {
+		return fg
+	}
+
+	o := fg.OKLCH()
+	// Move towards whichever end of the lightness axis has more room
+	target := 1.0
+	if Contrast(Color{A: 1}, bg) > Contrast(Color{R: 1, G: 1, B: 1, A: 1}, bg) {
+		target = 0
+	}
+
+	at := func(t float64) Color {
+		step := o
+		step.L = o.L + (target-o.L)*t
+		return step.Color(fg.A)
+	}
+	if !meets(at(1)) {
+		return at(1)
+	}
+	lo, hi := 0.0, 1.0
+	for i := 0; i < 24; i++ {
+		mid := (lo + hi) / 2
+		if meets(at(mid)) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return at(hi)
+}
@@ -0,0 +1,65 @@
+package csscolors
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"unicode"
+)
+
+// GoName returns the accessor name generated for a CSS variable:
+// gray900 → Gray900, blue200Alpha40 → Blue200Alpha40, pure-white → PureWhite
+func GoName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if r == '-' || r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// GenerateAccessors returns Go source for package pkg declaring a Colors
+// type with one method per variable in the CSS, so a misspelled or deleted
+// variable is a compile error instead of a MustGet panic. Each method's doc
+// comment gives the variable's name and value.
+func GenerateAccessors(pkg string, cssContent []byte) ([]byte, error) {
+	colors, err := LoadColorList(cssContent)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by internal/colorgen from colors.css; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	b.WriteString("import \"github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors\"\n\n")
+	b.WriteString("// Colors has one method per colors.css variable\n")
+	b.WriteString("type Colors struct {\n\tm csscolors.ColorMap\n}\n\n")
+	b.WriteString("// loadColors parses the embedded colors.css\n")
+	b.WriteString("func loadColors() (Colors, error) {\n")
+	b.WriteString("\tm, err := csscolors.LoadColors(colorsCSS)\n")
+	b.WriteString("\treturn Colors{m}, err\n}\n")
+
+	seen := map[string]string{}
+	for _, c := range colors {
+		method := GoName(c.Name)
+		if other, ok := seen[method]; ok {
+			return nil, fmt.Errorf("--%s and --%s both generate %s", other, c.Name, method)
+		}
+		seen[method] = c.Name
+
+		// Only the name and value, so editing a usage comment in colors.css
+		// doesn't regenerate the accessors
+		fmt.Fprintf(&b, "\n// %s is --%s: %s\n", method, c.Name, c.Value)
+		fmt.Fprintf(&b, "func (c Colors) %s() string { return c.m.MustGet(%q) }\n", method, c.Name)
+	}
+	return format.Source(b.Bytes())
+}
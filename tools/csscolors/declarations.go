@@ -0,0 +1,35 @@
+package csscolors
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// Declaration is one variable declaration as written in the CSS, with the
+// comment that follows it on the same line. Unlike LoadColorList it keeps
+// every declaration, so a variable declared twice appears twice.
+type Declaration struct {
+	Line    int // 1-based
+	Name    string
+	Value   string
+	Comment string
+}
+
+var declarationPattern = regexp.MustCompile(`^\s*--([\w-]+)\s*:\s*([^;]+?)\s*;\s*(?:/\*\s*(.*?)\s*\*/)?`)
+
+// LoadDeclarations scans a CSS file line by line for variable declarations.
+// Commented-out declarations are skipped.
+func LoadDeclarations(cssContent []byte) []Declaration {
+	var decls []Declaration
+	scanner := bufio.NewScanner(bytes.NewReader(cssContent))
+	for line := 1; scanner.Scan(); line++ {
+		m := declarationPattern.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		decls = append(decls, Declaration{Line: line, Name: m[1], Value: strings.TrimSpace(m[2]), Comment: m[3]})
+	}
+	return decls
+}
@@ -12,10 +12,41 @@ import (
 // ColorMap holds CSS variable names mapped to their color values
 type ColorMap map[string]string
 
+// NamedColor is a single CSS variable and its color value
+type NamedColor struct {
+	Name  string
+	Value string
+}
+
 // LoadColors parses an embedded CSS file and extracts all CSS custom properties (variables)
 // It returns a map of variable names (without the -- prefix) to their color values
 func LoadColors(cssContent []byte) (ColorMap, error) {
-	colors := make(ColorMap)
+	list, err := LoadColorList(cssContent)
+	if err != nil {
+		return nil, err
+	}
+
+	colors := make(ColorMap, len(list))
+	for _, c := range list {
+		colors[c.Name] = c.Value
+	}
+	return colors, nil
+}
+
+// LoadColorList parses an embedded CSS file like LoadColors, but returns the
+// variables in document order. Variables declared more than once keep the
+// position of their first declaration and the value of their last.
+func LoadColorList(cssContent []byte) ([]NamedColor, error) {
+	var list []NamedColor
+	index := make(map[string]int)
+	store := func(name, value string) {
+		if i, ok := index[name]; ok {
+			list[i].Value = value
+			return
+		}
+		index[name] = len(list)
+		list = append(list, NamedColor{Name: name, Value: value})
+	}
 
 	// Create a new CSS parser
 	input := parse.NewInputBytes(cssContent)
@@ -84,7 +115,7 @@ func LoadColors(cssContent []byte) (ColorMap, error) {
 						}
 
 						// Store in map
-						colors[varName] = colorValue
+						store(varName, colorValue)
 					}
 				}
 			}
@@ -106,7 +137,7 @@ func LoadColors(cssContent []byte) (ColorMap, error) {
 						colorValue := strings.TrimSpace(value.String())
 						colorValue = strings.TrimSuffix(colorValue, ";")
 						if !strings.HasPrefix(colorValue, "/*") {
-							colors[varName] = colorValue
+							store(varName, colorValue)
 						}
 					}
 				}
@@ -117,7 +148,7 @@ func LoadColors(cssContent []byte) (ColorMap, error) {
 		}
 	}
 
-	return colors, nil
+	return list, nil
 }
 
 // Get retrieves a color value by its variable name
@@ -0,0 +1,80 @@
+package csscolors
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Ramp is an ordered scale of color variables, declared in colors.css with
+// a comment listing the steps from darkest to lightest:
+//
+//	/* @ramp gray: gray900 gray800 gray700 */
+type Ramp struct {
+	Name  string
+	Steps []string
+}
+
+var rampPattern = regexp.MustCompile(`/\*\s*@ramp\s+(\w+)\s*:\s*([\w\s]+?)\s*\*/`)
+
+// LoadRamps returns the ramps declared in a CSS file. Every step must be a
+// variable defined in the same file.
+func LoadRamps(cssContent []byte) ([]Ramp, error) {
+	colors, err := LoadColors(cssContent)
+	if err != nil {
+		return nil, err
+	}
+
+	var ramps []Ramp
+	for _, m := range rampPattern.FindAllSubmatch(cssContent, -1) {
+		r := Ramp{Name: string(m[1]), Steps: strings.Fields(string(m[2]))}
+		for _, step := range r.Steps {
+			if _, ok := colors[step]; !ok {
+				return nil, fmt.Errorf("ramp %s: color variable '%s' not found in CSS", r.Name, step)
+			}
+		}
+		ramps = append(ramps, r)
+	}
+	return ramps, nil
+}
+
+// Index returns the position of a step in the ramp, or -1
+func (r Ramp) Index(name string) int {
+	for i, step := range r.Steps {
+		if step == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Step returns the variable n steps lighter than name (darker when n is
+// negative). It reports false when name is not in the ramp or the step
+// would fall off either end.
+func (r Ramp) Step(name string, n int) (string, bool) {
+	i := r.Index(name)
+	if i < 0 || i+n < 0 || i+n >= len(r.Steps) {
+		return "", false
+	}
+	return r.Steps[i+n], true
+}
+
+// Lighter returns the next lighter step after name
+func (r Ramp) Lighter(name string) (string, bool) {
+	return r.Step(name, 1)
+}
+
+// Darker returns the next darker step before name
+func (r Ramp) Darker(name string) (string, bool) {
+	return r.Step(name, -1)
+}
+
+// RampOf finds the ramp containing a variable
+func RampOf(ramps []Ramp, name string) (Ramp, bool) {
+	for _, r := range ramps {
+		if r.Index(name) >= 0 {
+			return r, true
+		}
+	}
+	return Ramp{}, false
+}
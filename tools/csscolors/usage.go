@@ -0,0 +1,94 @@
+package csscolors
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// Usages finds where palette source code uses each variable through its
+// generated accessor and returns the uses by variable name, in source
+// order. A use is the palette field assigned, "Background", with the
+// variant in parentheses for assignments inside GetFrostedPalette and
+// friends: "Background (frosted)". Calls the field can't be told for are
+// recorded as "". Commented-out code doesn't count.
+func Usages(goSource []byte, colors []NamedColor) (map[string][]string, error) {
+	names := make(map[string]string, len(colors))
+	for _, c := range colors {
+		names[GoName(c.Name)] = c.Name
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "palette.go", goSource, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := map[string][]string{}
+	add := func(name, use string) {
+		for _, u := range usages[name] {
+			if u == use {
+				return
+			}
+		}
+		usages[name] = append(usages[name], use)
+	}
+	// accessorCalls reports every accessor called within n
+	accessorCalls := func(n ast.Node, fn func(name string)) {
+		ast.Inspect(n, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) > 0 {
+				return true
+			}
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+				if name, ok := names[sel.Sel.Name]; ok {
+					fn(name)
+				}
+			}
+			return true
+		})
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		variant := strings.ToLower(strings.TrimSuffix(strings.TrimPrefix(fn.Name.Name, "Get"), "Palette"))
+		label := func(field string) string {
+			if variant == "" || field == "" {
+				return field
+			}
+			return field + " (" + variant + ")"
+		}
+
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			var field string
+			var value ast.Node
+			switch n := n.(type) {
+			case *ast.KeyValueExpr:
+				if key, ok := n.Key.(*ast.Ident); ok {
+					field, value = key.Name, n.Value
+				}
+			case *ast.AssignStmt:
+				if len(n.Lhs) == 1 && len(n.Rhs) == 1 {
+					if sel, ok := n.Lhs[0].(*ast.SelectorExpr); ok {
+						field, value = sel.Sel.Name, n.Rhs[0]
+					}
+				}
+			}
+			if value != nil {
+				accessorCalls(value, func(name string) { add(name, label(field)) })
+				return false
+			}
+			return true
+		})
+		// Anything else, e.g. an accessor passed to a helper
+		accessorCalls(fn.Body, func(name string) {
+			if _, ok := usages[name]; !ok {
+				add(name, "")
+			}
+		})
+	}
+	return usages, nil
+}
@@ -0,0 +1,258 @@
+package csscolors
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+// File is a colors.css open for editing. It keeps every line as written, so
+// section banners, blank lines and commented-out declarations survive, and
+// only rewrites the declaration lines that change.
+type File struct {
+	lines []string
+}
+
+// declLine splits a declaration line into indent, name, value, the space
+// before its comment, the comment and anything after the comment
+var declLine = regexp.MustCompile(`^(\s*)--([\w-]+)\s*:\s*([^;]+?)\s*;(\s*)(?:/\*\s*(.*?)\s*\*/)?(.*)$`)
+
+type declParts struct {
+	indent, name, value, gap, comment, rest string
+}
+
+func parseDecl(line string) (declParts, bool) {
+	m := declLine.FindStringSubmatch(line)
+	if m == nil {
+		return declParts{}, false
+	}
+	return declParts{indent: m[1], name: m[2], value: m[3], gap: m[4], comment: m[5], rest: m[6]}, true
+}
+
+func (d declParts) String() string {
+	line := d.indent + "--" + d.name + ": " + d.value + ";"
+	if d.comment != "" {
+		gap := d.gap
+		if gap == "" {
+			gap = " "
+		}
+		line += gap + "/* " + d.comment + " */"
+	}
+	return line + d.rest
+}
+
+// ParseFile opens CSS content for editing
+func ParseFile(cssContent []byte) *File {
+	return &File{lines: strings.Split(string(cssContent), "\n")}
+}
+
+// Bytes returns the edited CSS
+func (f *File) Bytes() []byte {
+	return []byte(strings.Join(f.lines, "\n"))
+}
+
+// Declarations returns the file's declarations as they stand after edits
+func (f *File) Declarations() []Declaration {
+	return LoadDeclarations(f.Bytes())
+}
+
+// find returns the line index and parts of a variable's declaration
+func (f *File) find(name string) (int, declParts, error) {
+	for i, line := range f.lines {
+		if d, ok := parseDecl(line); ok && d.name == name {
+			return i, d, nil
+		}
+	}
+	return -1, declParts{}, fmt.Errorf("color variable '%s' not found in CSS", name)
+}
+
+// edit applies fn to a variable's declaration and rewrites its line
+func (f *File) edit(name string, fn func(*declParts)) error {
+	i, d, err := f.find(name)
+	if err != nil {
+		return err
+	}
+	fn(&d)
+	f.lines[i] = d.String()
+	return nil
+}
+
+// Set changes a variable's value, keeping its comment
+func (f *File) Set(name, value string) error {
+	return f.edit(name, func(d *declParts) { d.value = value })
+}
+
+// SetComment replaces a variable's trailing comment. An empty comment
+// removes it.
+func (f *File) SetComment(name, comment string) error {
+	return f.edit(name, func(d *declParts) { d.comment = comment })
+}
+
+// Add declares a new variable on the line after the variable after, or at
+// the end of the rule when after is empty
+func (f *File) Add(name, value, comment, after string) error {
+	if _, _, err := f.find(name); err == nil {
+		return fmt.Errorf("color variable '%s' already declared", name)
+	}
+
+	d := declParts{indent: "  ", name: name, value: value, comment: comment}
+	at := -1
+	if after != "" {
+		i, prev, err := f.find(after)
+		if err != nil {
+			return err
+		}
+		at, d.indent = i+1, prev.indent
+	} else {
+		for i := len(f.lines) - 1; i >= 0; i-- {
+			if strings.TrimSpace(f.lines[i]) == "}" {
+				at = i
+				break
+			}
+		}
+		if at < 0 {
+			return fmt.Errorf("no closing brace to add '%s' before", name)
+		}
+	}
+	f.lines = append(f.lines[:at], append([]string{d.String()}, f.lines[at:]...)...)
+	return nil
+}
+
+// Rename renames a variable, along with its place in any @ramp comment
+func (f *File) Rename(oldName, newName string) error {
+	if _, _, err := f.find(newName); err == nil {
+		return fmt.Errorf("color variable '%s' already declared", newName)
+	}
+	if err := f.edit(oldName, func(d *declParts) { d.name = newName }); err != nil {
+		return err
+	}
+	f.editRamps(func(step string) string {
+		if step == oldName {
+			return newName
+		}
+		return step
+	})
+	return nil
+}
+
+// Remove deletes a variable's declaration and drops it from any @ramp
+// comment
+func (f *File) Remove(name string) error {
+	i, _, err := f.find(name)
+	if err != nil {
+		return err
+	}
+	f.lines = append(f.lines[:i], f.lines[i+1:]...)
+	f.editRamps(func(step string) string {
+		if step == name {
+			return ""
+		}
+		return step
+	})
+	return nil
+}
+
+// editRamps maps every step of every @ramp comment through fn; steps
+// mapped to "" are dropped
+func (f *File) editRamps(fn func(step string) string) {
+	for i, line := range f.lines {
+		loc := rampPattern.FindStringSubmatchIndex(line)
+		if loc == nil {
+			continue
+		}
+		var steps []string
+		for _, step := range strings.Fields(line[loc[4]:loc[5]]) {
+			if step = fn(step); step != "" {
+				steps = append(steps, step)
+			}
+		}
+		f.lines[i] = line[:loc[4]] + strings.Join(steps, " ") + line[loc[5]:]
+	}
+}
+
+var hexValue = regexp.MustCompile(`^#([0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+
+// CanonicalHex returns a hex color as lowercase #rrggbbaa. Other values are
+// returned unchanged.
+func CanonicalHex(value string) string {
+	m := hexValue.FindStringSubmatch(value)
+	if m == nil {
+		return value
+	}
+	hex := strings.ToLower(m[1])
+	if len(hex) <= 4 {
+		var expanded strings.Builder
+		for _, r := range hex {
+			expanded.WriteString(strings.Repeat(string(r), 2))
+		}
+		hex = expanded.String()
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	return "#" + hex
+}
+
+// Format canonicalizes every declaration: hex colors become lowercase
+// #rrggbbaa and spacing becomes "--name: value; /* comment */"
+func (f *File) Format() {
+	for i, line := range f.lines {
+		if d, ok := parseDecl(line); ok {
+			d.value = CanonicalHex(d.value)
+			if d.comment != "" {
+				d.gap = " "
+			}
+			f.lines[i] = d.String()
+		}
+	}
+}
+
+var usageItem = regexp.MustCompile(`^([A-Z][A-Za-z0-9]*)( \([a-z ]+\))?$`)
+
+// usageFields are the names a field list may hold: the palette's color
+// fields and Accents
+var usageFields = func() map[string]bool {
+	fields := map[string]bool{"Accents": true}
+	for _, field := range palette.ColorFields() {
+		fields[field] = true
+	}
+	return fields
+}()
+
+// SplitComment separates a declaration comment that starts with the palette
+// fields using the color, "Surface, EditorSubheader - LineHighlight", into
+// those fields and the description after " - ". Comments that don't start
+// with a list of real palette fields, such as "Deprecated", are all
+// description.
+func SplitComment(comment string) (usage []string, description string) {
+	head, desc, found := strings.Cut(comment, " - ")
+	items := strings.Split(head, ", ")
+	for _, item := range items {
+		m := usageItem.FindStringSubmatch(item)
+		if m == nil || !usageFields[m[1]] {
+			return nil, comment
+		}
+	}
+	if !found {
+		desc = ""
+	}
+	return items, desc
+}
+
+// SetUsage replaces the field list at the start of a variable's comment,
+// keeping its description
+func (f *File) SetUsage(name string, usage []string) error {
+	return f.edit(name, func(d *declParts) {
+		_, desc := SplitComment(d.comment)
+		parts := []string{}
+		if len(usage) > 0 {
+			parts = append(parts, strings.Join(usage, ", "))
+		}
+		if desc != "" {
+			parts = append(parts, desc)
+		}
+		d.comment = strings.Join(parts, " - ")
+	})
+}
@@ -0,0 +1,153 @@
+package csscolors
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sampleCSS = `:root {
+  /* ==========
+     Grays
+     ========== */
+
+  /* @ramp gray: gray900 gray800 */
+  --gray900: #14191FFF; /* Background - Darkest */
+  --gray800:   #1c2128;   /* Surface */
+  /* --gray700: #2a3039ff; */ /* Unused */
+  --blue200Alpha40: #6ee2ff66;
+}
+`
+
+func TestFileEdits(t *testing.T) {
+	f := ParseFile([]byte(sampleCSS))
+	if err := f.Set("gray900", "#101010ff"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Rename("gray800", "gray820"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Add("gray600", "#323842ff", "Border", "gray820"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Add("pureWhite", "#ffffffff", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Remove("blue200Alpha40"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `:root {
+  /* ==========
+     Grays
+     ========== */
+
+  /* @ramp gray: gray900 gray820 */
+  --gray900: #101010ff; /* Background - Darkest */
+  --gray820: #1c2128;   /* Surface */
+  --gray600: #323842ff; /* Border */
+  /* --gray700: #2a3039ff; */ /* Unused */
+  --pureWhite: #ffffffff;
+}
+`
+	if got := string(f.Bytes()); got != want {
+		t.Errorf("edited CSS:\n%s\nwant:\n%s", got, want)
+	}
+
+	for _, err := range []error{
+		f.Set("missing", "#000000ff"),
+		f.Rename("gray900", "gray600"),
+		f.Add("gray900", "#000000ff", "", ""),
+	} {
+		if err == nil {
+			t.Error("expected an error")
+		}
+	}
+}
+
+func TestFileFormat(t *testing.T) {
+	f := ParseFile([]byte(sampleCSS))
+	f.Format()
+	got := string(f.Bytes())
+	for _, line := range []string{
+		"  --gray900: #14191fff; /* Background - Darkest */",
+		"  --gray800: #1c2128ff; /* Surface */",
+		"  /* --gray700: #2a3039ff; */ /* Unused */",
+	} {
+		if !strings.Contains(got, line+"\n") {
+			t.Errorf("formatted CSS is missing %q:\n%s", line, got)
+		}
+	}
+
+	for in, want := range map[string]string{
+		"#ABC":      "#aabbccff",
+		"#abcd":     "#aabbccdd",
+		"#A1B2C3":   "#a1b2c3ff",
+		"#a1b2c3d4": "#a1b2c3d4",
+		"red":       "red",
+	} {
+		if got := CanonicalHex(in); got != want {
+			t.Errorf("CanonicalHex(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSplitComment(t *testing.T) {
+	for comment, want := range map[string]struct {
+		usage []string
+		desc  string
+	}{
+		"Surface, EditorSubheader - LineHighlight":  {[]string{"Surface", "EditorSubheader"}, "LineHighlight"},
+		"Selection (frosted) - semi-transparent":    {[]string{"Selection (frosted)"}, "semi-transparent"},
+		"Comment":                                   {[]string{"Comment"}, ""},
+		"Deprecated":                                {nil, "Deprecated"},
+		"Unused - kept for the light variant":       {nil, "Unused - kept for the light variant"},
+		"Keyword, Accents - Primary blue":           {[]string{"Keyword", "Accents"}, "Primary blue"},
+		"Special variables (self/this/super)":       {nil, "Special variables (self/this/super)"},
+		"Neon orange with 25% opacity - for search": {nil, "Neon orange with 25% opacity - for search"},
+	} {
+		usage, desc := SplitComment(comment)
+		if !reflect.DeepEqual(usage, want.usage) || desc != want.desc {
+			t.Errorf("SplitComment(%q) = %q, %q; want %q, %q", comment, usage, desc, want.usage, want.desc)
+		}
+	}
+
+	f := ParseFile([]byte(sampleCSS))
+	if err := f.SetUsage("gray900", []string{"Background", "EditorBackground"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(f.Bytes()), "/* Background, EditorBackground - Darkest */") {
+		t.Errorf("SetUsage lost the description:\n%s", f.Bytes())
+	}
+}
+
+func TestUsages(t *testing.T) {
+	src := `package dark
+
+func GetPalette() TronThemePalette {
+	return TronThemePalette{
+		Background: colors.Gray900(),
+		// Surface: colors.Gray700(),
+		Accents: []string{colors.Gray800(), colors.Gray900()},
+	}
+}
+
+func GetFrostedPalette() TronThemePalette {
+	p := GetPalette()
+	p.Surface = colors.Gray800()
+	return p
+}
+`
+	colors := []NamedColor{{Name: "gray900"}, {Name: "gray800"}, {Name: "gray700"}}
+	got, err := Usages([]byte(src), colors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"gray900": {"Background", "Accents"},
+		"gray800": {"Accents", "Surface (frosted)"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Usages = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,162 @@
+// Package cvd audits that theme colors which carry meaning (errors vs.
+// additions, diff lines, multiplayer cursors) remain distinguishable for
+// dichromats. The simulations themselves live in colormath.Dichromacy:
+// Machado et al. (2009) for protanopia and deuteranopia, Brettel et al.
+// (1997) for tritanopia.
+package cvd
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"sort"
+	"strings"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+// Deficiency is a type of dichromacy
+type Deficiency string
+
+const (
+	Protanopia   Deficiency = "protanopia"   // missing L (red) cones
+	Deuteranopia Deficiency = "deuteranopia" // missing M (green) cones
+	Tritanopia   Deficiency = "tritanopia"   // missing S (blue) cones
+)
+
+// Deficiencies lists every simulated deficiency
+var Deficiencies = []Deficiency{Protanopia, Deuteranopia, Tritanopia}
+
+// Dichromacy returns the colormath simulation of the deficiency
+func (d Deficiency) Dichromacy() colormath.Dichromacy {
+	switch d {
+	case Protanopia:
+		return colormath.Protanopia
+	case Deuteranopia:
+		return colormath.Deuteranopia
+	case Tritanopia:
+		return colormath.Tritanopia
+	}
+	panic("cvd: unknown deficiency " + string(d))
+}
+
+// Pair is two theme colors that must stay distinguishable
+type Pair struct {
+	Name string
+	A, B string
+}
+
+// CriticalPairs returns the color pairs whose difference carries meaning:
+// diff plus/minus, error vs. success and every pair of distinct multiplayer
+// cursors. Players sharing a cursor color are compared only once.
+func CriticalPairs(style *palette.ThemeStyle) []Pair {
+	pairs := []Pair{
+		{Name: "diff.plus / diff.minus", A: style.Syntax.DiffPlus.Color, B: style.Syntax.DiffMinus.Color},
+		{Name: "error / success", A: style.Error, B: style.Success},
+		{Name: "version_control.added / deleted", A: style.VersionControlAdded, B: style.VersionControlDeleted},
+	}
+
+	var cursors []string
+	first := map[string]int{}
+	for i, player := range style.Players {
+		if _, seen := first[strings.ToLower(player.Cursor)]; seen {
+			continue
+		}
+		first[strings.ToLower(player.Cursor)] = i
+		cursors = append(cursors, player.Cursor)
+	}
+	for i := range cursors {
+		for j := i + 1; j < len(cursors); j++ {
+			pairs = append(pairs, Pair{
+				Name: fmt.Sprintf("players[%d].cursor / players[%d].cursor", first[strings.ToLower(cursors[i])], first[strings.ToLower(cursors[j])]),
+				A:    cursors[i],
+				B:    cursors[j],
+			})
+		}
+	}
+	return pairs
+}
+
+// VersionControlPairs returns the added/modified/deleted pairs. Stock Tron
+// relies on green vs. yellow here, so only the colorblind-friendly variants
+// are held to them.
+func VersionControlPairs(style *palette.ThemeStyle) []Pair {
+	return []Pair{
+		{Name: "version_control.added / modified", A: style.VersionControlAdded, B: style.VersionControlModified},
+		{Name: "version_control.modified / deleted", A: style.VersionControlModified, B: style.VersionControlDeleted},
+	}
+}
+
+// Result is the simulated difference of one pair under one deficiency.
+// Deficiency is empty for normal vision.
+type Result struct {
+	Pair       Pair
+	Deficiency Deficiency
+	SimA, SimB colormath.Color
+	DeltaE     float64
+}
+
+// MinDeltaE is the smallest CIEDE2000 difference a critical pair may have
+// under any simulation. It is about twice the just noticeable difference,
+// enough to tell thin strokes such as cursors apart at a glance.
+const MinDeltaE = 4.0
+
+// Audit composites every critical pair over the editor background and
+// measures CIEDE2000 under normal vision and each deficiency.
+func Audit(style *palette.ThemeStyle, backdrop colormath.Color) ([]Result, error) {
+	return AuditPairs(style, backdrop, CriticalPairs(style))
+}
+
+// AuditPairs is Audit for an arbitrary set of pairs. Results are sorted from
+// least to most distinguishable.
+func AuditPairs(style *palette.ThemeStyle, backdrop colormath.Color, pairs []Pair) ([]Result, error) {
+	bg, err := colormath.ParseHex(style.EditorBackground)
+	if err != nil {
+		return nil, fmt.Errorf("editor.background: %w", err)
+	}
+	bg = bg.Over(backdrop)
+
+	var results []Result
+	for _, pair := range pairs {
+		a, err := colormath.ParseHex(pair.A)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", pair.Name, err)
+		}
+		b, err := colormath.ParseHex(pair.B)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", pair.Name, err)
+		}
+		a, b = a.Over(bg), b.Over(bg)
+
+		results = append(results, Result{Pair: pair, SimA: a, SimB: b, DeltaE: colormath.DeltaE(a, b)})
+		for _, d := range Deficiencies {
+			m := d.Dichromacy()
+			sa, sb := m.Simulate(a), m.Simulate(b)
+			results = append(results, Result{Pair: pair, Deficiency: d, SimA: sa, SimB: sb, DeltaE: colormath.DeltaE(sa, sb)})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].DeltaE < results[j].DeltaE })
+	return results, nil
+}
+
+// SimulateImage returns a copy of img as seen with the deficiency
+func SimulateImage(img *image.NRGBA, d Deficiency) *image.NRGBA {
+	m := d.Dichromacy()
+	out := image.NewNRGBA(img.Rect)
+	// Screenshots only use a few hundred distinct colors
+	cache := map[color.NRGBA]color.NRGBA{}
+	for y := img.Rect.Min.Y; y < img.Rect.Max.Y; y++ {
+		for x := img.Rect.Min.X; x < img.Rect.Max.X; x++ {
+			c := img.NRGBAAt(x, y)
+			sim, ok := cache[c]
+			if !ok {
+				sim = m.Simulate(colormath.FromNRGBA(c)).NRGBA()
+				cache[c] = sim
+			}
+			out.SetNRGBA(x, y, sim)
+		}
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package cvd
+
+import (
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/dark"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+func TestAuditPairs(t *testing.T) {
+	style := &palette.ThemeStyle{EditorBackground: "#14191fff"}
+	black := colormath.Color{A: 1}
+	pairs := []Pair{
+		{Name: "red / green", A: "#d04040ff", B: "#40a040ff"},
+		{Name: "red / blue", A: "#d04040ff", B: "#4060e0ff"},
+	}
+	results, err := AuditPairs(style, black, pairs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(pairs)*(len(Deficiencies)+1) {
+		t.Fatalf("got %d results, want one per pair for normal vision and each deficiency", len(results))
+	}
+	for i := 1; i < len(results); i++ {
+		if results[i].DeltaE < results[i-1].DeltaE {
+			t.Fatal("results are not sorted from least to most distinguishable")
+		}
+	}
+	// Red and green are the least distinguishable pair, for a red-green
+	// dichromat
+	if worst := results[0]; worst.Pair.Name != "red / green" || (worst.Deficiency != Protanopia && worst.Deficiency != Deuteranopia) {
+		t.Errorf("worst result is %s for %q", worst.Pair.Name, worst.Deficiency)
+	}
+
+	// Translucent colors are composited over the editor background first
+	translucent, err := AuditPairs(style, black, []Pair{{Name: "faint", A: "#d0404000", B: "#40a04000"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range translucent {
+		if r.DeltaE != 0 {
+			t.Errorf("%s: fully transparent colors differ by ΔE %.2f", r.Deficiency, r.DeltaE)
+		}
+	}
+
+	if _, err := AuditPairs(style, black, []Pair{{Name: "broken", A: "#zz0000ff", B: "#000000ff"}}); err == nil {
+		t.Error("expected an error for an invalid color")
+	}
+}
+
+func TestAudit(t *testing.T) {
+	style := palette.GenerateThemeStyle("Tron Legacy", "dark", dark.GetPalette()).Style
+	results, err := Audit(style, colormath.Color{A: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := len(CriticalPairs(style)) * (len(Deficiencies) + 1); len(results) != want {
+		t.Errorf("got %d results, want %d", len(results), want)
+	}
+}
@@ -0,0 +1,196 @@
+// Code generated by internal/colorgen from colors.css; DO NOT EDIT.
+
+package dark
+
+import "github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+
+// Colors has one method per colors.css variable
+type Colors struct {
+	m csscolors.ColorMap
+}
+
+// loadColors parses the embedded colors.css
+func loadColors() (Colors, error) {
+	m, err := csscolors.LoadColors(colorsCSS)
+	return Colors{m}, err
+}
+
+// Black is --black: #000000ff
+func (c Colors) Black() string { return c.m.MustGet("black") }
+
+// PureWhite is --pureWhite: #ffffffff
+func (c Colors) PureWhite() string { return c.m.MustGet("pureWhite") }
+
+// Transparent is --transparent: #00000000
+func (c Colors) Transparent() string { return c.m.MustGet("transparent") }
+
+// Shadow is --shadow: #00000040
+func (c Colors) Shadow() string { return c.m.MustGet("shadow") }
+
+// Gray900 is --gray900: #14191fff
+func (c Colors) Gray900() string { return c.m.MustGet("gray900") }
+
+// Gray800 is --gray800: #1c2128ff
+func (c Colors) Gray800() string { return c.m.MustGet("gray800") }
+
+// Gray750 is --gray750: #23282fff
+func (c Colors) Gray750() string { return c.m.MustGet("gray750") }
+
+// Gray725 is --gray725: #242a33ff
+func (c Colors) Gray725() string { return c.m.MustGet("gray725") }
+
+// Neutral800 is --neutral800: #1a1d23ff
+func (c Colors) Neutral800() string { return c.m.MustGet("neutral800") }
+
+// Gray900Alpha93 is --gray900Alpha93: #14191fee
+func (c Colors) Gray900Alpha93() string { return c.m.MustGet("gray900Alpha93") }
+
+// Gray900Frosted is --gray900Frosted: #14191fcc
+func (c Colors) Gray900Frosted() string { return c.m.MustGet("gray900Frosted") }
+
+// Gray800Alpha33 is --gray800Alpha33: #1c212855
+func (c Colors) Gray800Alpha33() string { return c.m.MustGet("gray800Alpha33") }
+
+// Gray800Alpha75 is --gray800Alpha75: #1c2128bf
+func (c Colors) Gray800Alpha75() string { return c.m.MustGet("gray800Alpha75") }
+
+// Gray800Alpha80 is --gray800Alpha80: #1c2128cc
+func (c Colors) Gray800Alpha80() string { return c.m.MustGet("gray800Alpha80") }
+
+// Gray750Alpha80 is --gray750Alpha80: #23282fcc
+func (c Colors) Gray750Alpha80() string { return c.m.MustGet("gray750Alpha80") }
+
+// Gray700 is --gray700: #2a3039ff
+func (c Colors) Gray700() string { return c.m.MustGet("gray700") }
+
+// Gray650 is --gray650: #2e333cff
+func (c Colors) Gray650() string { return c.m.MustGet("gray650") }
+
+// Neutral600 is --neutral600: #2d3139ff
+func (c Colors) Neutral600() string { return c.m.MustGet("neutral600") }
+
+// Gray700Alpha40 is --gray700Alpha40: #2a303966
+func (c Colors) Gray700Alpha40() string { return c.m.MustGet("gray700Alpha40") }
+
+// Gray600Alpha40 is --gray600Alpha40: #32384266
+func (c Colors) Gray600Alpha40() string { return c.m.MustGet("gray600Alpha40") }
+
+// Gray600Alpha67 is --gray600Alpha67: #323842aa
+func (c Colors) Gray600Alpha67() string { return c.m.MustGet("gray600Alpha67") }
+
+// Gray500Alpha20 is --gray500Alpha20: #647c9b33
+func (c Colors) Gray500Alpha20() string { return c.m.MustGet("gray500Alpha20") }
+
+// Gray550 is --gray550: #586676ff
+func (c Colors) Gray550() string { return c.m.MustGet("gray550") }
+
+// Gray500 is --gray500: #647c9bff
+func (c Colors) Gray500() string { return c.m.MustGet("gray500") }
+
+// Gray450 is --gray450: #7891b0ff
+func (c Colors) Gray450() string { return c.m.MustGet("gray450") }
+
+// Gray200 is --gray200: #aec2e0ff
+func (c Colors) Gray200() string { return c.m.MustGet("gray200") }
+
+// Gray50 is --gray50: #dae3f1ff
+func (c Colors) Gray50() string { return c.m.MustGet("gray50") }
+
+// Gray500Alpha25 is --gray500Alpha25: #647c9b40
+func (c Colors) Gray500Alpha25() string { return c.m.MustGet("gray500Alpha25") }
+
+// Gray500Frosted is --gray500Frosted: #647c9bf2
+func (c Colors) Gray500Frosted() string { return c.m.MustGet("gray500Frosted") }
+
+// Gray550Alpha35 is --gray550Alpha35: #58667659
+func (c Colors) Gray550Alpha35() string { return c.m.MustGet("gray550Alpha35") }
+
+// Gray200Frosted is --gray200Frosted: #aec2e0ee
+func (c Colors) Gray200Frosted() string { return c.m.MustGet("gray200Frosted") }
+
+// Blue500 is --blue500: #267fb5ff
+func (c Colors) Blue500() string { return c.m.MustGet("blue500") }
+
+// Blue300 is --blue300: #4a95b3ff
+func (c Colors) Blue300() string { return c.m.MustGet("blue300") }
+
+// Blue200 is --blue200: #6ee2ffff
+func (c Colors) Blue200() string { return c.m.MustGet("blue200") }
+
+// Blue200Bright is --blue200Bright: #c8d9e8ff
+func (c Colors) Blue200Bright() string { return c.m.MustGet("blue200Bright") }
+
+// Blue500Alpha24 is --blue500Alpha24: #267fb53d
+func (c Colors) Blue500Alpha24() string { return c.m.MustGet("blue500Alpha24") }
+
+// Blue200Alpha10 is --blue200Alpha10: #6ee2ff1a
+func (c Colors) Blue200Alpha10() string { return c.m.MustGet("blue200Alpha10") }
+
+// Blue200Alpha13 is --blue200Alpha13: #6ee2ff22
+func (c Colors) Blue200Alpha13() string { return c.m.MustGet("blue200Alpha13") }
+
+// Blue200Alpha09 is --blue200Alpha09: #6ee2ff18
+func (c Colors) Blue200Alpha09() string { return c.m.MustGet("blue200Alpha09") }
+
+// Blue200Alpha20 is --blue200Alpha20: #6ee2ff33
+func (c Colors) Blue200Alpha20() string { return c.m.MustGet("blue200Alpha20") }
+
+// Blue200Alpha27 is --blue200Alpha27: #6ee2ff44
+func (c Colors) Blue200Alpha27() string { return c.m.MustGet("blue200Alpha27") }
+
+// Blue200Alpha40 is --blue200Alpha40: #6ee2ff66
+func (c Colors) Blue200Alpha40() string { return c.m.MustGet("blue200Alpha40") }
+
+// Blue200Alpha50 is --blue200Alpha50: #6ee2ff80
+func (c Colors) Blue200Alpha50() string { return c.m.MustGet("blue200Alpha50") }
+
+// Blue200Alpha60 is --blue200Alpha60: #6ee2ff99
+func (c Colors) Blue200Alpha60() string { return c.m.MustGet("blue200Alpha60") }
+
+// Green700 is --green700: #144212ff
+func (c Colors) Green700() string { return c.m.MustGet("green700") }
+
+// Green600 is --green600: #4d5f07ff
+func (c Colors) Green600() string { return c.m.MustGet("green600") }
+
+// Green500 is --green500: #95cc5eff
+func (c Colors) Green500() string { return c.m.MustGet("green500") }
+
+// Green300 is --green300: #c7f026ff
+func (c Colors) Green300() string { return c.m.MustGet("green300") }
+
+// Yellow500 is --yellow500: #ffe792ff
+func (c Colors) Yellow500() string { return c.m.MustGet("yellow500") }
+
+// Yellow400 is --yellow400: #ffd12cff
+func (c Colors) Yellow400() string { return c.m.MustGet("yellow400") }
+
+// Orange500 is --orange500: #ffb20dff
+func (c Colors) Orange500() string { return c.m.MustGet("orange500") }
+
+// Orange400 is --orange400: #f79d1eff
+func (c Colors) Orange400() string { return c.m.MustGet("orange400") }
+
+// NeonOrangeAlpha25 is --neonOrangeAlpha25: #ff660040
+func (c Colors) NeonOrangeAlpha25() string { return c.m.MustGet("neonOrangeAlpha25") }
+
+// Red700 is --red700: #660000ff
+func (c Colors) Red700() string { return c.m.MustGet("red700") }
+
+// Red500 is --red500: #f92672ff
+func (c Colors) Red500() string { return c.m.MustGet("red500") }
+
+// Red400 is --red400: #ff410dff
+func (c Colors) Red400() string { return c.m.MustGet("red400") }
+
+// Red300 is --red300: #ff5f52ff
+func (c Colors) Red300() string { return c.m.MustGet("red300") }
+
+// Purple500 is --purple500: #967efbff
+func (c Colors) Purple500() string { return c.m.MustGet("purple500") }
+
+// Pink500 is --pink500: #ff79c6ff
+func (c Colors) Pink500() string { return c.m.MustGet("pink500") }
+
+// Pink400 is --pink400: #ffb3e1ff
+func (c Colors) Pink400() string { return c.m.MustGet("pink400") }
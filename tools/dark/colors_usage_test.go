@@ -1,19 +1,51 @@
 package dark
 
 import (
-	_ "embed"
+	"os"
 	"testing"
 
 	"github.com/bcomnes/zed-theme-tron-legacy/tools/utils/colorvalidation"
 )
 
-//go:embed palette.go
-var paletteGoContent []byte
-
 func TestAllColorsUsed(t *testing.T) {
-	colorvalidation.ValidateAllColorsUsed(t, colorsCSS, paletteGoContent)
+	colorvalidation.ValidateAllColorsUsed(t, colorsCSS, paletteSource)
 }
 
 func TestNoDuplicateColorValues(t *testing.T) {
 	colorvalidation.ValidateNoDuplicateColorValues(t, colorsCSS)
 }
+
+func TestNoNearDuplicateColors(t *testing.T) {
+	// neutral600 is the shipped Border; it stays apart from the gray ramp
+	// so the ramp can move without changing every border
+	colorvalidation.ValidateNoNearDuplicateColors(t, colorsCSS, colorvalidation.DefaultNearDuplicateDeltaE,
+		[2]string{"gray650", "neutral600"})
+}
+
+func TestSyntaxRoles(t *testing.T) {
+	colorvalidation.ValidateSyntaxRoles(t, GetPalette(), colorvalidation.DefaultNearDuplicateDeltaE)
+}
+
+func TestColorNames(t *testing.T) {
+	colorvalidation.ValidateColorNames(t, colorsCSS)
+}
+
+func TestOpacityComments(t *testing.T) {
+	colorvalidation.ValidateOpacityComments(t, colorsCSS, paletteSource)
+}
+
+func TestRampOrder(t *testing.T) {
+	colorvalidation.ValidateRampOrder(t, colorsCSS)
+}
+
+func TestAccessorsUpToDate(t *testing.T) {
+	generated, err := os.ReadFile("colors_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	colorvalidation.ValidateAccessorsUpToDate(t, "dark", colorsCSS, generated)
+}
+
+func TestFormatted(t *testing.T) {
+	colorvalidation.ValidateFormatted(t, colorsCSS)
+}
@@ -0,0 +1,55 @@
+package derive
+
+import (
+	"strings"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+// accentFields are drawn in the accent color itself: focused borders, the
+// active line number and active indent guides (UIAccent)
+var accentFields = []string{"BorderFocused", "UIAccent"}
+
+// tintFields are translucent washes of the accent: scrollbar hover, drop
+// targets, document highlights and the local selection (Player1). They keep
+// their alpha and take the accent's color.
+var tintFields = []string{"ScrollbarThumbHover", "ScrollbarThumbActive", "DropTarget",
+	"DocumentHighlight", "DocumentHighlightWrite", "Player1"}
+
+// Accent re-drives the UI accent of p with a new color, recreating looks
+// like Clu's orange from the same palette. The accent is adjusted to reach
+// the non-text contrast minimum on the background, the adjusted color moves
+// to the front of Accents, and selection is tinted towards its hue.
+func Accent(p palette.TronThemePalette, accent string) palette.TronThemePalette {
+	c := colormath.MustParseHex(accent).Opaque()
+	c = colormath.EnsureContrast(c, colormath.MustParseHex(p.Background).Opaque(), NonText)
+
+	for _, field := range accentFields {
+		p.Set(field, c.Hex())
+	}
+	for _, field := range tintFields {
+		value, _ := p.Get(field)
+		if tint, err := colormath.ParseHex(value); err == nil {
+			p.Set(field, c.WithAlpha(tint.A).Hex())
+		}
+	}
+
+	// Selection stays a neutral gray, just leaning towards the accent
+	hue := c.OKLCH().H
+	p = Apply(p, []string{"Selection"}, func(o colormath.OKLCH) colormath.OKLCH {
+		o.H = hue
+		o.C = max(o.C, 0.02)
+		return o
+	})
+
+	// The raw accent is replaced by its adjusted color
+	accents := []string{c.Hex()}
+	for _, a := range p.Accents {
+		if !strings.EqualFold(a, c.Hex()) && !strings.EqualFold(a, accent) {
+			accents = append(accents, a)
+		}
+	}
+	p.Accents = accents
+	return p
+}
@@ -0,0 +1,64 @@
+package derive
+
+import (
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/dark"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/light"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+func TestAccent(t *testing.T) {
+	cases := []struct {
+		name string
+		base palette.TronThemePalette
+		css  []byte
+	}{
+		{"dark", dark.GetPalette(), dark.ColorsCSS()},
+		{"light", light.GetPalette(), light.ColorsCSS()},
+	}
+	for _, tc := range cases {
+		name, base := tc.name, tc.base
+		colors, err := csscolors.LoadColors(tc.css)
+		if err != nil {
+			t.Fatal(err)
+		}
+		orange := colors.MustGet("orange500")
+		p := Accent(base, orange)
+		hue := colormath.MustParseHex(orange).OKLCH().H
+		bg := colormath.MustParseHex(p.Background)
+
+		for _, field := range accentFields {
+			value, _ := p.Get(field)
+			c := colormath.MustParseHex(value)
+			if d := hueDistance(c.OKLCH().H, hue); d > 5 {
+				t.Errorf("%s: %s %s is %.1f° off the accent hue", name, field, value, d)
+			}
+			if got := colormath.Contrast(c, bg); got < NonText {
+				t.Errorf("%s: %s %s has contrast %.2f, want >= %.1f", name, field, value, got, NonText)
+			}
+		}
+		for _, field := range tintFields {
+			before, _ := base.Get(field)
+			after, _ := p.Get(field)
+			b, a := colormath.MustParseHex(before), colormath.MustParseHex(after)
+			if a.A != b.A {
+				t.Errorf("%s: %s alpha changed from %.2f to %.2f", name, field, b.A, a.A)
+			}
+			if d := hueDistance(a.OKLCH().H, hue); d > 5 {
+				t.Errorf("%s: %s %s is %.1f° off the accent hue", name, field, after, d)
+			}
+		}
+
+		if p.Accents[0] != p.BorderFocused || len(p.Accents) != len(base.Accents) {
+			t.Errorf("%s: accents = %v, want the adjusted accent %s first", name, p.Accents, p.BorderFocused)
+		}
+
+		// Syntax and status colors are untouched
+		if p.Keyword != base.Keyword || p.Info != base.Info || p.Error != base.Error {
+			t.Errorf("%s: accent changed syntax or status colors", name)
+		}
+	}
+}
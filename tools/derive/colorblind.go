@@ -0,0 +1,161 @@
+package derive
+
+import (
+	"math"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/cvd"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+// Semantic slots that rely on red/green hue differences. Between them they
+// cover every color cvd.VersionControlPairs checks: version_control.added
+// and diff.plus are drawn in Success, version_control.deleted and
+// diff.minus in Error, and version_control.modified in VCSModified.
+var (
+	successFields  = []string{"Success", "SuccessSurface", "TerminalGreen", "TerminalBrightGreen", "TerminalDimGreen"}
+	errorFields    = []string{"Error", "ErrorSurface", "TerminalRed", "TerminalBrightRed", "TerminalDimRed"}
+	modifiedFields = []string{"VCSModified"}
+)
+
+// hueRange is the arc of OKLCH hues a slot may be rotated within, from Min
+// counterclockwise to Max, so it still reads as the color it names
+type hueRange struct {
+	Min, Max float64
+}
+
+// Success stays green to teal, error red to crimson and modified yellow to
+// amber. Blues and oranges are never touched, so the Tron identity survives.
+var (
+	successHues  = hueRange{Min: 100, Max: 200}
+	errorHues    = hueRange{Min: 340, Max: 40}
+	modifiedHues = hueRange{Min: 50, Max: 110}
+)
+
+// hueStep is the resolution of the hue search in degrees
+const hueStep = 5
+
+// hues are the OKLCH hues success, error and modified colors are rotated to
+type hues struct {
+	Success, Error, Modified float64
+}
+
+// colorblindHues searches every combination of success, error and modified
+// hues for the one whose closest pair (success/error, success/modified,
+// modified/error) is furthest apart under normal vision and under d's
+// colormath.Dichromacy projection, with the slots keeping their lightness
+// and chroma. The other deficiencies only have to reach cvd.MinDeltaE,
+// where possible. Ties go to the combination that moves hues least.
+func colorblindHues(p palette.TronThemePalette, d cvd.Deficiency) hues {
+	bg := colormath.MustParseHex(p.EditorBackground).Over(colormath.MustParseHex(p.Background).Over(colormath.Color{A: 1}))
+
+	// A slot's color at each candidate hue, as seen normally and with each
+	// deficiency
+	type candidate struct {
+		hue  float64
+		seen []colormath.Color
+	}
+	candidates := func(value string, r hueRange) []candidate {
+		c := colormath.MustParseHex(value)
+		var out []candidate
+		for _, h := range r.steps() {
+			rotated := c.Map(WithHue(h)).Over(bg)
+			seen := []colormath.Color{rotated}
+			for _, other := range cvd.Deficiencies {
+				seen = append(seen, other.Dichromacy().Simulate(rotated))
+			}
+			out = append(out, candidate{h, seen})
+		}
+		return out
+	}
+	success := candidates(p.Success, successHues)
+	errs := candidates(p.Error, errorHues)
+	modified := candidates(p.VCSModified, modifiedHues)
+
+	// score is how far apart a pair stays for the target and the others
+	type score struct{ target, others float64 }
+	distance := func(a, b candidate) score {
+		s := score{target: colormath.DeltaE(a.seen[0], b.seen[0]), others: cvd.MinDeltaE}
+		for i, other := range cvd.Deficiencies {
+			dE := colormath.DeltaE(a.seen[i+1], b.seen[i+1])
+			if other == d {
+				s.target = math.Min(s.target, dE)
+			} else {
+				s.others = math.Min(s.others, dE)
+			}
+		}
+		return s
+	}
+	worst := func(a, b score) score {
+		return score{math.Min(a.target, b.target), math.Min(a.others, b.others)}
+	}
+	original := func(value string) float64 { return colormath.MustParseHex(value).OKLCH().H }
+	moved := func(h hues) float64 {
+		return hueDistance(h.Success, original(p.Success)) + hueDistance(h.Error, original(p.Error)) +
+			hueDistance(h.Modified, original(p.VCSModified))
+	}
+
+	distances := func(as, bs []candidate) [][]score {
+		out := make([][]score, len(as))
+		for i, a := range as {
+			for _, b := range bs {
+				out[i] = append(out[i], distance(a, b))
+			}
+		}
+		return out
+	}
+	successError, successModified, modifiedError := distances(success, errs), distances(success, modified), distances(modified, errs)
+
+	var best hues
+	bestScore := score{-1, -1}
+	for i, s := range success {
+		for j, e := range errs {
+			for k, mod := range modified {
+				sc := worst(successError[i][j], worst(successModified[i][k], modifiedError[k][j]))
+				h := hues{Success: s.hue, Error: e.hue, Modified: mod.hue}
+				switch {
+				case sc.others != bestScore.others:
+					if sc.others < bestScore.others {
+						continue
+					}
+				case sc.target != bestScore.target:
+					if sc.target < bestScore.target {
+						continue
+					}
+				case moved(h) >= moved(best):
+					continue
+				}
+				best, bestScore = h, sc
+			}
+		}
+	}
+	return best
+}
+
+// steps returns the hues of r every hueStep degrees
+func (r hueRange) steps() []float64 {
+	var out []float64
+	span := math.Mod(r.Max-r.Min+360, 360)
+	for d := 0.0; d <= span; d += hueStep {
+		out = append(out, math.Mod(r.Min+d, 360))
+	}
+	return out
+}
+
+// hueDistance is the angle between two hues in degrees, at most 180
+func hueDistance(a, b float64) float64 {
+	d := math.Mod(math.Abs(a-b), 360)
+	return math.Min(d, 360-d)
+}
+
+// ColorblindFriendly remaps the success, error and version control slots of
+// p (including terminal red and green) to hues that stay apart for the given
+// deficiency, see colorblindHues. Lightness and chroma are kept, so contrast
+// is unchanged.
+func ColorblindFriendly(p palette.TronThemePalette, d cvd.Deficiency) palette.TronThemePalette {
+	h := colorblindHues(p, d)
+	p = Apply(p, successFields, WithHue(h.Success))
+	p = Apply(p, errorFields, WithHue(h.Error))
+	p = Apply(p, modifiedFields, WithHue(h.Modified))
+	return p
+}
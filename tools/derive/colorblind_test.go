@@ -0,0 +1,60 @@
+package derive
+
+import (
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/cvd"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/dark"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/light"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+func TestColorblindFriendly(t *testing.T) {
+	inRange := func(h float64, r hueRange) bool {
+		for _, step := range r.steps() {
+			if hueDistance(h, step) < 0.5 {
+				return true
+			}
+		}
+		return false
+	}
+	worstVCS := func(p palette.TronThemePalette, d cvd.Deficiency) float64 {
+		style := palette.GenerateThemeStyle("", "", p).Style
+		results, err := cvd.AuditPairs(style, colormath.Color{A: 1}, cvd.VersionControlPairs(style))
+		if err != nil {
+			t.Fatal(err)
+		}
+		worst := 100.0
+		for _, r := range results {
+			if r.Deficiency == d {
+				worst = min(worst, r.DeltaE)
+			}
+		}
+		return worst
+	}
+
+	for _, base := range []palette.TronThemePalette{dark.GetPalette(), light.GetPalette()} {
+		for _, d := range cvd.Deficiencies {
+			h := colorblindHues(base, d)
+			if !inRange(h.Success, successHues) || !inRange(h.Error, errorHues) || !inRange(h.Modified, modifiedHues) {
+				t.Errorf("%s: hues %+v leave their ranges", d, h)
+			}
+
+			// Every version control color is remapped, not just modified
+			p := ColorblindFriendly(base, d)
+			for _, slot := range []struct {
+				field string
+				hue   float64
+			}{{"Success", h.Success}, {"Error", h.Error}, {"VCSModified", h.Modified}} {
+				value, _ := p.Get(slot.field)
+				if got := colormath.MustParseHex(value).OKLCH().H; hueDistance(got, slot.hue) > 2 {
+					t.Errorf("%s: %s has hue %.1f, want %.1f", d, slot.field, got, slot.hue)
+				}
+			}
+			if before, after := worstVCS(base, d), worstVCS(p, d); after < before || after < cvd.MinDeltaE {
+				t.Errorf("%s: version control pairs ΔE %.2f, was %.2f", d, after, before)
+			}
+		}
+	}
+}
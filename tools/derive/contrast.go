@@ -0,0 +1,109 @@
+package derive
+
+import (
+	"strings"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+// WCAG contrast targets
+const (
+	// AAA is the enhanced contrast ratio for body text (WCAG 1.4.6)
+	AAA = 7.0
+	// NonText is the minimum ratio for borders and UI controls (WCAG 1.4.11)
+	NonText = 3.0
+)
+
+// TextFields returns the palette fields drawn as text: the foreground
+// layer, status colors, line numbers, syntax, terminal and version control
+// colors. ANSI black and white are skipped since one of them is always
+// meant to blend into the background.
+func TextFields() []string {
+	fields := []string{"Foreground", "ForegroundMuted", "ForegroundStrong", "LineNumber",
+		"Error", "Warning", "Success", "Info", "Hint", "Accent", "UIAccent", "VCSModified"}
+	for _, section := range palette.Sections() {
+		switch section.Group {
+		case "Syntax Highlighting":
+			fields = append(fields, section.Fields...)
+		case "Terminal Colors (ANSI)":
+			for _, field := range section.Fields {
+				if !strings.HasSuffix(field, "Black") && !strings.HasSuffix(field, "White") {
+					fields = append(fields, field)
+				}
+			}
+		}
+	}
+	return fields
+}
+
+// TextBackgrounds returns the palette fields text is drawn on top of
+var TextBackgrounds = []string{"Background", "EditorBackground", "BackgroundElevated", "BackgroundOverlay",
+	"Surface", "SurfaceHighlight", "EditorSubheader", "Statusbar", "StatusbarInactive"}
+
+// minFillAlpha is the opacity below which HighContrast removes a fill: such
+// a faint tint is easy to miss and only muddies the background
+const minFillAlpha = 0.15
+
+// borderFields must stand out from the background as UI controls
+var borderFields = []string{"Border", "BorderSubtle", "BorderFocused", "ScrollbarTrackBorder", "GuideActive"}
+
+// Backgrounds flattens every text background of p over the desktop color
+func Backgrounds(p palette.TronThemePalette, desktop colormath.Color) []colormath.Color {
+	base := colormath.MustParseHex(p.Background).Over(desktop)
+	var out []colormath.Color
+	for _, field := range TextBackgrounds {
+		value, _ := p.Get(field)
+		if c, err := colormath.ParseHex(value); err == nil {
+			out = append(out, c.Over(base))
+		}
+	}
+	return out
+}
+
+// EnsureContrast pushes the lightness of the named fields until each reaches
+// ratio against every background
+func EnsureContrast(p palette.TronThemePalette, fields []string, backgrounds []colormath.Color, ratio float64) palette.TronThemePalette {
+	for _, field := range fields {
+		value, _ := p.Get(field)
+		c, err := colormath.ParseHex(value)
+		if err != nil {
+			continue
+		}
+		// Backgrounds share a side of the lightness axis, so each pass only
+		// moves further from all of them
+		for _, bg := range backgrounds {
+			c = colormath.EnsureContrast(c, bg, ratio)
+		}
+		p.Set(field, c.Hex())
+	}
+	return p
+}
+
+// HighContrast derives a high contrast palette from p: every text and
+// syntax color meets WCAG AAA against every background, borders meet the
+// non-text minimum, and translucent fills are made opaque by flattening them
+// over the editor, except those below minFillAlpha, which are removed.
+// Compressing every color into the high contrast lightness range can merge
+// hues for colorblind users, so the result is run through Distinguish.
+func HighContrast(p palette.TronThemePalette, desktop colormath.Color) palette.TronThemePalette {
+	backgrounds := Backgrounds(p, desktop)
+	editor := colormath.MustParseHex(p.EditorBackground).Over(backgrounds[0])
+
+	for _, field := range palette.ColorFields() {
+		value, _ := p.Get(field)
+		c, err := colormath.ParseHex(value)
+		if err != nil || c.A == 0 || c.IsOpaque() {
+			continue
+		}
+		if c.A < minFillAlpha {
+			p.Set(field, p.Transparent)
+			continue
+		}
+		p.Set(field, c.Over(editor).Hex())
+	}
+
+	p = EnsureContrast(p, borderFields, backgrounds, NonText)
+	p = EnsureContrast(p, TextFields(), backgrounds, AAA)
+	return Distinguish(p, desktop)
+}
@@ -0,0 +1,71 @@
+package derive
+
+import (
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/dark"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/light"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+func TestHighContrast(t *testing.T) {
+	cases := []struct {
+		name    string
+		base    palette.TronThemePalette
+		desktop colormath.Color
+	}{
+		{"dark", dark.GetPalette(), colormath.Color{A: 1}},
+		{"light", light.GetPalette(), colormath.Color{R: 1, G: 1, B: 1, A: 1}},
+	}
+
+	for _, tc := range cases {
+		p := HighContrast(tc.base, tc.desktop)
+		backgrounds := Backgrounds(p, tc.desktop)
+
+		check := func(fields []string, ratio float64) {
+			for _, field := range fields {
+				value, _ := p.Get(field)
+				c := colormath.MustParseHex(value)
+				for _, bg := range backgrounds {
+					if got := colormath.Contrast(c, bg); got < ratio {
+						t.Errorf("%s: %s %s has contrast %.2f on %s, want >= %.1f", tc.name, field, value, got, bg.Hex(), ratio)
+					}
+				}
+			}
+		}
+		check(TextFields(), AAA)
+		check(borderFields, NonText)
+
+		for _, field := range palette.ColorFields() {
+			value, _ := p.Get(field)
+			if value == "" {
+				continue
+			}
+			if c := colormath.MustParseHex(value); c.A > 0 && !c.IsOpaque() {
+				t.Errorf("%s: %s %s is still translucent", tc.name, field, value)
+			}
+			before, _ := tc.base.Get(field)
+			if c := colormath.MustParseHex(before); c.A > 0 && c.A < minFillAlpha && colormath.MustParseHex(value).A != 0 {
+				t.Errorf("%s: faint fill %s %s was kept as %s", tc.name, field, before, value)
+			}
+		}
+
+		// Hues survive so the variant still reads as Tron
+		for _, field := range []string{"Keyword", "Function", "String"} {
+			before, _ := tc.base.Get(field)
+			after, _ := p.Get(field)
+			hb, ha := colormath.MustParseHex(before).OKLCH().H, colormath.MustParseHex(after).OKLCH().H
+			if d := min(abs(hb-ha), 360-abs(hb-ha)); d > 10 {
+				t.Errorf("%s: %s hue moved %.1f° (%s → %s)", tc.name, field, d, before, after)
+			}
+		}
+	}
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,58 @@
+// Package derive builds new theme palettes from the hand-tuned dark and
+// light palettes by transforming their colors in OKLCH, so derived variants
+// inherit every future palette change automatically.
+package derive
+
+import (
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+// Transform maps a single color in OKLCH. Alpha is handled by the caller.
+type Transform func(colormath.OKLCH) colormath.OKLCH
+
+// Apply runs fn over the named color fields of p. Fields that are not hex
+// colors (e.g. "transparent" keywords) are left alone.
+func Apply(p palette.TronThemePalette, fields []string, fn Transform) palette.TronThemePalette {
+	for _, field := range fields {
+		value, ok := p.Get(field)
+		if !ok {
+			continue
+		}
+		if mapped, ok := mapHex(value, fn); ok {
+			p.Set(field, mapped)
+		}
+	}
+	return p
+}
+
+// ApplyAll runs fn over every color of p, including the accents
+func ApplyAll(p palette.TronThemePalette, fn Transform) palette.TronThemePalette {
+	p = Apply(p, palette.ColorFields(), fn)
+	accents := make([]string, len(p.Accents))
+	for i, accent := range p.Accents {
+		accents[i] = accent
+		if mapped, ok := mapHex(accent, fn); ok {
+			accents[i] = mapped
+		}
+	}
+	p.Accents = accents
+	return p
+}
+
+func mapHex(value string, fn Transform) (string, bool) {
+	c, err := colormath.ParseHex(value)
+	if err != nil {
+		return "", false
+	}
+	return c.Map(fn).Hex(), true
+}
+
+// WithHue returns a transform that rotates colors to hue h, keeping their
+// lightness and chroma
+func WithHue(h float64) Transform {
+	return func(o colormath.OKLCH) colormath.OKLCH {
+		o.H = h
+		return o
+	}
+}
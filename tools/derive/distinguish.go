@@ -0,0 +1,72 @@
+package derive
+
+import (
+	"strings"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/cvd"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+// Distinguish nudges colors of p apart until every cvd.CriticalPairs entry
+// reaches cvd.MinDeltaE under normal vision and each deficiency. Transforms
+// that squeeze lightness (e.g. enforcing AAA on a white background) pull
+// hues like Type and Info together; each step moves the color of the worst
+// pair that already has more contrast a little further from the background,
+// so contrast targets keep holding.
+func Distinguish(p palette.TronThemePalette, desktop colormath.Color) palette.TronThemePalette {
+	const step = 0.01
+
+	bg := colormath.MustParseHex(p.EditorBackground).Over(colormath.MustParseHex(p.Background).Over(desktop))
+	for range 100 {
+		style := palette.GenerateThemeStyle("", "", p).Style
+		results, err := cvd.Audit(style, desktop)
+		if err != nil || len(results) == 0 || results[0].DeltaE >= cvd.MinDeltaE {
+			break
+		}
+
+		a, b := results[0].Pair.A, results[0].Pair.B
+		ca, cb := colormath.MustParseHex(a), colormath.MustParseHex(b)
+		move := a
+		if colormath.Contrast(cb.Over(bg), bg) > colormath.Contrast(ca.Over(bg), bg) {
+			move = b
+		}
+		if !nudge(&p, move, bg, step) {
+			// Already at the end of the lightness axis, move the other one
+			if move == a {
+				move = b
+			} else {
+				move = a
+			}
+			if !nudge(&p, move, bg, step) {
+				break
+			}
+		}
+	}
+	return p
+}
+
+// nudge moves every field of p holding value one step further from bg in
+// OKLCH lightness. Fields sharing a color move together so they stay
+// aliased. It reports whether anything changed.
+func nudge(p *palette.TronThemePalette, value string, bg colormath.Color, step float64) bool {
+	c := colormath.MustParseHex(value)
+	direction := step
+	if bg.OKLCH().L > 0.5 {
+		direction = -step
+	}
+	moved := c.Map(func(o colormath.OKLCH) colormath.OKLCH {
+		o.L += direction
+		return o
+	}).Hex()
+	if strings.EqualFold(moved, c.Hex()) {
+		return false
+	}
+
+	for _, field := range palette.ColorFields() {
+		if v, _ := p.Get(field); strings.EqualFold(v, value) {
+			p.Set(field, moved)
+		}
+	}
+	return true
+}
@@ -0,0 +1,173 @@
+package derive
+
+import (
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+// FrostedOptions sets the opacity of each translucent layer
+type FrostedOptions struct {
+	// BackgroundOpacity applies to the window background, title and status
+	// bars and overlays
+	BackgroundOpacity float64
+	// EditorOpacity applies to the editor background
+	EditorOpacity float64
+	// BorderOpacity applies to borders; subtle borders and selections get
+	// subtleRatio of it
+	BorderOpacity float64
+	// MinContrast is the WCAG ratio text that meets it on the opaque palette
+	// must keep over any desktop
+	MinContrast float64
+}
+
+// subtleRatio is how much fainter subtle borders and selections are than
+// borders, as in the hand-tuned dark frosted palette (40% vs. 67%)
+const subtleRatio = 0.6
+
+// FrostedLite is barely translucent, for busy wallpapers
+var FrostedLite = FrostedOptions{BackgroundOpacity: 0.9, EditorOpacity: 0.97, BorderOpacity: 0.8, MinContrast: 4.5}
+
+// FrostedHeavy lets much of the wallpaper through. Its text is held to the
+// 3:1 large-text minimum; AA over a white desktop would wash the terminal
+// colors out to pastels.
+var FrostedHeavy = FrostedOptions{BackgroundOpacity: 0.65, EditorOpacity: 0.82, BorderOpacity: 0.5, MinContrast: 3}
+
+// Frosted returns p with the translucent layers of a frosted variant
+// computed from opts. Elevated surfaces such as popovers stay opaque for
+// readability. Text colors that CheckFrosted flags are pushed away from the
+// background until they meet opts.MinContrast again, the way the hand-tuned
+// frosted palettes brighten their foregrounds, and critical pairs are kept
+// apart for colorblind users.
+func Frosted(p palette.TronThemePalette, opts FrostedOptions) palette.TronThemePalette {
+	base := p
+	withAlpha := func(field string, alpha float64) {
+		value, _ := p.Get(field)
+		if c, err := colormath.ParseHex(value); err == nil {
+			p.Set(field, c.WithAlpha(alpha).Hex())
+		}
+	}
+
+	p.BackgroundAppearance = "blurred"
+	p.Surface = p.Transparent
+	for _, field := range []string{"Background", "Statusbar", "StatusbarInactive", "BackgroundOverlay", "BackgroundOverlayHover"} {
+		withAlpha(field, opts.BackgroundOpacity)
+	}
+	withAlpha("EditorBackground", opts.EditorOpacity)
+
+	// The terminal's dim blue often shares the border color but stays
+	// opaque: terminal colors are drawn as text, not over the wallpaper
+	withAlpha("Border", opts.BorderOpacity)
+	withAlpha("BorderSubtle", opts.BorderOpacity*subtleRatio)
+	withAlpha("Selection", opts.BorderOpacity*subtleRatio)
+
+	// Fixing one desktop can expose another, so repeat until stable
+	for range 4 {
+		issues := CheckFrosted(base, p, opts.MinContrast)
+		if len(issues) == 0 {
+			break
+		}
+		for _, issue := range issues {
+			value, _ := p.Get(issue.Field)
+			c := colormath.EnsureContrast(colormath.MustParseHex(value), issue.Background, opts.MinContrast)
+			p.Set(issue.Field, c.Hex())
+		}
+	}
+
+	// Brightening text squeezes hues together, see Distinguish
+	for _, desktop := range desktops {
+		p = Distinguish(p, desktop)
+	}
+	return p
+}
+
+// ContrastIssue is a text color that meets the contrast target on the
+// opaque palette but falls below it once frosted
+type ContrastIssue struct {
+	Field   string
+	Surface string
+	Desktop colormath.Color
+	// Background is the surface as composited over Desktop
+	Background colormath.Color
+	Contrast   float64
+	Opaque     float64
+}
+
+// Overrides lists the fields where frosted differs from base, in palette
+// order, as "Field: value" lines
+func Overrides(base, frosted palette.TronThemePalette) []string {
+	var lines []string
+	if base.BackgroundAppearance != frosted.BackgroundAppearance {
+		lines = append(lines, "BackgroundAppearance: "+frosted.BackgroundAppearance)
+	}
+	for _, field := range palette.ColorFields() {
+		a, _ := base.Get(field)
+		b, _ := frosted.Get(field)
+		if a != b {
+			lines = append(lines, field+": "+b)
+		}
+	}
+	return lines
+}
+
+// desktops are the extremes a blurred wallpaper can show through
+var desktops = []colormath.Color{{A: 1}, {R: 1, G: 1, B: 1, A: 1}}
+
+// surfaces returns the translucent layers a text field is read on: syntax
+// sits on the editor, the terminal on the window background and UI text on
+// both
+func surfaces(field string) []string {
+	for _, section := range palette.Sections() {
+		for _, f := range section.Fields {
+			if f != field {
+				continue
+			}
+			switch section.Group {
+			case "Syntax Highlighting":
+				return []string{"EditorBackground"}
+			case "Terminal Colors (ANSI)":
+				return []string{"Background"}
+			}
+		}
+	}
+	return []string{"Background", "EditorBackground"}
+}
+
+// CheckFrosted composites the translucent surfaces of frosted over a black
+// and a white desktop, the two extremes a blurred wallpaper can show
+// through. A text color is reported when it reaches minContrast on the
+// opaque base palette but not on the frosted one over either desktop, so
+// frosting never costs text its legibility.
+func CheckFrosted(base, frosted palette.TronThemePalette, minContrast float64) []ContrastIssue {
+	// The editor is drawn on top of the window background
+	layer := func(p palette.TronThemePalette, surface string, desktop colormath.Color) colormath.Color {
+		window := colormath.MustParseHex(p.Background).Over(desktop)
+		if surface == "Background" {
+			return window
+		}
+		value, _ := p.Get(surface)
+		return colormath.MustParseHex(value).Over(window)
+	}
+
+	var issues []ContrastIssue
+	for _, desktop := range desktops {
+		for _, field := range TextFields() {
+			value, _ := frosted.Get(field)
+			c, err := colormath.ParseHex(value)
+			if err != nil {
+				continue
+			}
+			value, _ = base.Get(field)
+			opaqueColor := colormath.MustParseHex(value)
+
+			for _, surface := range surfaces(field) {
+				opaqueBg, bg := layer(base, surface, desktop), layer(frosted, surface, desktop)
+				opaque := colormath.Contrast(opaqueColor.Over(opaqueBg), opaqueBg)
+				got := colormath.Contrast(c.Over(bg), bg)
+				if opaque >= minContrast && got < minContrast {
+					issues = append(issues, ContrastIssue{Field: field, Surface: surface, Desktop: desktop, Contrast: got, Opaque: opaque, Background: bg})
+				}
+			}
+		}
+	}
+	return issues
+}
@@ -0,0 +1,40 @@
+package derive
+
+import (
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/dark"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/light"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+func TestFrosted(t *testing.T) {
+	bases := map[string]palette.TronThemePalette{"dark": dark.GetPalette(), "light": light.GetPalette()}
+	presets := map[string]FrostedOptions{"lite": FrostedLite, "heavy": FrostedHeavy}
+
+	for baseName, base := range bases {
+		for presetName, opts := range presets {
+			p := Frosted(base, opts)
+			for _, issue := range CheckFrosted(base, p, opts.MinContrast) {
+				t.Errorf("%s %s: %s on %s over %s desktop has contrast %.2f, %.2f when opaque",
+					baseName, presetName, issue.Field, issue.Surface, issue.Desktop.Hex(), issue.Contrast, issue.Opaque)
+			}
+
+			if p.BackgroundAppearance != "blurred" {
+				t.Errorf("%s %s: background appearance is %q", baseName, presetName, p.BackgroundAppearance)
+			}
+			for field, want := range map[string]float64{
+				"Background":       opts.BackgroundOpacity,
+				"EditorBackground": opts.EditorOpacity,
+				"Border":           opts.BorderOpacity,
+				"BorderSubtle":     opts.BorderOpacity * subtleRatio,
+			} {
+				value, _ := p.Get(field)
+				if got := colormath.MustParseHex(value).A; got < want-0.01 || got > want+0.01 {
+					t.Errorf("%s %s: %s alpha is %.2f, want %.2f", baseName, presetName, field, got, want)
+				}
+			}
+		}
+	}
+}
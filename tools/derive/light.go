@@ -0,0 +1,317 @@
+package derive
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+// LightOptions controls how a light palette is derived from the dark one
+type LightOptions struct {
+	// BackgroundL is the OKLCH lightness the dark Background maps to
+	BackgroundL float64
+	// ForegroundL is the OKLCH lightness the dark ForegroundStrong maps to
+	ForegroundL float64
+	// TextContrast is the minimum WCAG ratio for text against every background
+	TextContrast float64
+}
+
+// DefaultLightOptions matches the lightness range of the hand-tuned light theme
+func DefaultLightOptions() LightOptions {
+	return LightOptions{
+		BackgroundL:  0.975,
+		ForegroundL:  0.25,
+		TextContrast: 4.5,
+	}
+}
+
+// inkChroma separates saturated inks from tinted grays
+const inkChroma = 0.04
+
+// LightProposal is a light palette derived from the dark colors.css. Colors
+// keep their dark variable names until UseNames renames them after the
+// light ones.
+type LightProposal struct {
+	Colors  []csscolors.NamedColor
+	Palette palette.TronThemePalette
+
+	// Source holds the dark variable each color was derived from
+	Source map[string]string
+	// Dark holds the source value of every variable
+	Dark map[string]string
+	// Fields lists the palette fields using each variable
+	Fields map[string][]string
+}
+
+// ProposeLight derives a light palette in OKLCH, keeping every hue:
+//
+//   - grays and dark tints (surfaces, selections) have their lightness
+//     inverted around the Background/ForegroundStrong anchors
+//   - bright saturated inks keep their chroma and are darkened only as far
+//     as needed to reach opts.TextContrast on the derived background, since
+//     a plain inversion would turn neon yellow into brown
+//   - translucent black shadows and transparent colors are kept as is
+//
+// Finally every text field is checked against every derived background.
+func ProposeLight(darkCSS []byte, dark palette.TronThemePalette, opts LightOptions) (*LightProposal, error) {
+	colors, err := csscolors.LoadColorList(darkCSS)
+	if err != nil {
+		return nil, err
+	}
+
+	bgL := colormath.MustParseHex(dark.Background).OKLCH().L
+	fgL := colormath.MustParseHex(dark.ForegroundStrong).OKLCH().L
+	invert := func(o colormath.OKLCH) colormath.OKLCH {
+		o.L = opts.BackgroundL + (o.L-bgL)*(opts.ForegroundL-opts.BackgroundL)/(fgL-bgL)
+		return o
+	}
+
+	background := colormath.MustParseHex(dark.Background).Map(invert)
+
+	lp := &LightProposal{Source: map[string]string{}, Dark: map[string]string{}, Fields: map[string][]string{}}
+	byValue := map[string]string{}
+	index := map[string]int{}
+	for _, nc := range colors {
+		c, err := colormath.ParseHex(nc.Value)
+		if err != nil {
+			return nil, fmt.Errorf("--%s: %w", nc.Name, err)
+		}
+		lp.Source[nc.Name] = nc.Name
+		lp.Dark[nc.Name] = nc.Value
+		byValue[strings.ToLower(nc.Value)] = nc.Name
+		index[nc.Name] = len(lp.Colors)
+
+		switch o := c.OKLCH(); {
+		case c.A == 0 || (!c.IsOpaque() && o.L == 0):
+		case o.C < inkChroma || o.L < 0.5:
+			c = c.Map(invert)
+		default:
+			c = colormath.EnsureContrast(c.Opaque(), background, opts.TextContrast).WithAlpha(c.A)
+		}
+		lp.Colors = append(lp.Colors, csscolors.NamedColor{Name: nc.Name, Value: c.Hex()})
+	}
+
+	// Rebuild the palette from the proposed variables
+	lp.Palette = dark
+	fieldVar := map[string]string{}
+	for _, field := range palette.ColorFields() {
+		value, _ := dark.Get(field)
+		name, ok := byValue[strings.ToLower(value)]
+		if !ok {
+			continue
+		}
+		fieldVar[field] = name
+		lp.Fields[name] = append(lp.Fields[name], field)
+		lp.Palette.Set(field, lp.Colors[index[name]].Value)
+	}
+	lp.Palette.Accents = nil
+	for _, accent := range dark.Accents {
+		if name, ok := byValue[strings.ToLower(accent)]; ok {
+			accent = lp.Colors[index[name]].Value
+		}
+		lp.Palette.Accents = append(lp.Palette.Accents, accent)
+	}
+
+	// Enforce contrast on the variables so colors.css and the palette agree
+	backgrounds := Backgrounds(lp.Palette, colormath.Color{R: 1, G: 1, B: 1, A: 1})
+	for _, field := range TextFields() {
+		name, ok := fieldVar[field]
+		if !ok {
+			continue
+		}
+		c := colormath.MustParseHex(lp.Colors[index[name]].Value)
+		for _, bg := range backgrounds {
+			c = colormath.EnsureContrast(c, bg, opts.TextContrast)
+		}
+		lp.Colors[index[name]].Value = c.Hex()
+		for _, f := range lp.Fields[name] {
+			lp.Palette.Set(f, c.Hex())
+		}
+	}
+	return lp, nil
+}
+
+// ColorsCSS renders the proposal as a colors.css file for review
+func (lp *LightProposal) ColorsCSS() []byte {
+	var b strings.Builder
+	b.WriteString("/* Tron Legacy Light Theme Colors (derived from dark/colors.css) */\n")
+	b.WriteString("/* Generated by `go run generate-theme.go derive-light`; review before adopting */\n\n")
+	b.WriteString(":root {\n")
+	for _, c := range lp.Colors {
+		fmt.Fprintf(&b, "  --%s: %s; /* from", c.Name, c.Value)
+		if src := lp.Source[c.Name]; src != c.Name {
+			fmt.Fprintf(&b, " --%s", src)
+		}
+		fmt.Fprintf(&b, " %s", lp.Dark[c.Name])
+		if fields := lp.Fields[c.Name]; len(fields) > 0 {
+			fmt.Fprintf(&b, " - %s", strings.Join(fields, ", "))
+		}
+		b.WriteString(" */\n")
+	}
+	b.WriteString("}\n")
+	return []byte(b.String())
+}
+
+// UseNames renames the proposed variables after the light colors.css they
+// would replace, so the two files can be diffed: each variable takes the
+// name of the light variable most of its palette fields use there.
+// Variables no light field matches keep their dark name, with a "Derived"
+// suffix if another variable already took it.
+func (lp *LightProposal) UseNames(lightCSS []byte, light palette.TronThemePalette) error {
+	colors, err := csscolors.LoadColorList(lightCSS)
+	if err != nil {
+		return err
+	}
+	byValue := map[string]string{}
+	for _, c := range colors {
+		byValue[strings.ToLower(c.Value)] = c.Name
+	}
+
+	// Let the variables backing the most fields pick their names first
+	type vote struct {
+		from, to string
+		n        int
+	}
+	var votes []vote
+	for _, c := range lp.Colors {
+		counts := map[string]int{}
+		var order []string
+		for _, field := range lp.Fields[c.Name] {
+			value, _ := light.Get(field)
+			name, ok := byValue[strings.ToLower(value)]
+			if !ok {
+				continue
+			}
+			if counts[name] == 0 {
+				order = append(order, name)
+			}
+			counts[name]++
+		}
+		for _, name := range order {
+			votes = append(votes, vote{c.Name, name, counts[name]})
+		}
+	}
+	sort.SliceStable(votes, func(i, j int) bool { return votes[i].n > votes[j].n })
+
+	rename := map[string]string{}
+	taken := map[string]bool{}
+	for _, v := range votes {
+		if _, done := rename[v.from]; done || taken[v.to] {
+			continue
+		}
+		rename[v.from] = v.to
+		taken[v.to] = true
+	}
+	for _, c := range lp.Colors {
+		if _, done := rename[c.Name]; done {
+			continue
+		}
+		name := c.Name
+		if taken[name] {
+			name += "Derived"
+		}
+		rename[c.Name] = name
+		taken[name] = true
+	}
+
+	source, dark, fields := map[string]string{}, map[string]string{}, map[string][]string{}
+	for i, c := range lp.Colors {
+		name := rename[c.Name]
+		source[name] = lp.Source[c.Name]
+		dark[name] = lp.Dark[c.Name]
+		if f, ok := lp.Fields[c.Name]; ok {
+			fields[name] = f
+		}
+		lp.Colors[i].Name = name
+	}
+	lp.Source, lp.Dark, lp.Fields = source, dark, fields
+	return nil
+}
+
+// CSSChange is a variable whose declaration differs between the current
+// light colors.css and the proposal
+type CSSChange struct {
+	Name string
+	// Current is empty for a variable the proposal adds
+	Current string
+	// Proposed is empty for a variable the proposal removes
+	Proposed string
+	// DeltaE compares a changed value, composited over its palette's
+	// Background when translucent
+	DeltaE float64
+}
+
+// Diff compares the current light colors.css with the proposal variable by
+// variable: changed and removed variables in light's order, then added
+// ones. Call UseNames first so the names line up.
+func (lp *LightProposal) Diff(lightCSS []byte, light palette.TronThemePalette) ([]CSSChange, error) {
+	colors, err := csscolors.LoadColorList(lightCSS)
+	if err != nil {
+		return nil, err
+	}
+	proposed := map[string]string{}
+	for _, c := range lp.Colors {
+		proposed[c.Name] = c.Value
+	}
+
+	white := colormath.Color{R: 1, G: 1, B: 1, A: 1}
+	currentBg := colormath.MustParseHex(light.Background).Over(white)
+	proposedBg := colormath.MustParseHex(lp.Palette.Background).Over(white)
+
+	var changes []CSSChange
+	current := map[string]bool{}
+	for _, c := range colors {
+		current[c.Name] = true
+		value, ok := proposed[c.Name]
+		switch {
+		case !ok:
+			changes = append(changes, CSSChange{Name: c.Name, Current: c.Value})
+		case !strings.EqualFold(value, c.Value):
+			change := CSSChange{Name: c.Name, Current: c.Value, Proposed: value}
+			a, errA := colormath.ParseHex(c.Value)
+			b, errB := colormath.ParseHex(value)
+			if errA == nil && errB == nil {
+				change.DeltaE = colormath.DeltaE(a.Over(currentBg), b.Over(proposedBg))
+			}
+			changes = append(changes, change)
+		}
+	}
+	for _, c := range lp.Colors {
+		if !current[c.Name] {
+			changes = append(changes, CSSChange{Name: c.Name, Proposed: c.Value})
+		}
+	}
+	return changes, nil
+}
+
+// WriteDiff prints changes as a diff from the light colors.css to the
+// proposal. Changed values closer than threshold are left out and only
+// counted.
+func WriteDiff(w io.Writer, changes []CSSChange, threshold float64) {
+	fmt.Fprintln(w, "--- light/colors.css")
+	fmt.Fprintln(w, "+++ derived colors.css")
+	var changed, minor, added, removed int
+	for _, c := range changes {
+		switch {
+		case c.Proposed == "":
+			removed++
+			fmt.Fprintf(w, "-  --%s: %s;\n", c.Name, c.Current)
+		case c.Current == "":
+			added++
+			fmt.Fprintf(w, "+  --%s: %s;\n", c.Name, c.Proposed)
+		case c.DeltaE < threshold:
+			minor++
+		default:
+			changed++
+			fmt.Fprintf(w, "-  --%s: %s;\n", c.Name, c.Current)
+			fmt.Fprintf(w, "+  --%s: %s; /* ΔE %.1f */\n", c.Name, c.Proposed, c.DeltaE)
+		}
+	}
+	fmt.Fprintf(w, "\n%d variables differ from the derived proposal by ΔE >= %.1f (%d by less), %d added, %d removed\n",
+		changed, threshold, minor, added, removed)
+}
@@ -0,0 +1,106 @@
+package derive
+
+import (
+	"bytes"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/dark"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/light"
+)
+
+func TestProposeLight(t *testing.T) {
+	opts := DefaultLightOptions()
+	lp, err := ProposeLight(dark.ColorsCSS(), dark.GetPalette(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := colormath.MustParseHex(lp.Palette.Background).OKLCH().L; l < opts.BackgroundL-0.01 {
+		t.Errorf("Background %s has lightness %.3f, want about %.3f", lp.Palette.Background, l, opts.BackgroundL)
+	}
+
+	backgrounds := Backgrounds(lp.Palette, colormath.Color{R: 1, G: 1, B: 1, A: 1})
+	for _, field := range TextFields() {
+		value, _ := lp.Palette.Get(field)
+		c := colormath.MustParseHex(value)
+		for _, bg := range backgrounds {
+			if got := colormath.Contrast(c, bg); got < opts.TextContrast {
+				t.Errorf("%s %s has contrast %.2f on %s, want >= %.1f", field, value, got, bg.Hex(), opts.TextContrast)
+			}
+		}
+	}
+
+	// Saturated colors keep their hue
+	darkPalette := dark.GetPalette()
+	for _, field := range []string{"Keyword", "Function", "String", "Number", "Decorator"} {
+		before, _ := darkPalette.Get(field)
+		after, _ := lp.Palette.Get(field)
+		hb, ha := colormath.MustParseHex(before).OKLCH().H, colormath.MustParseHex(after).OKLCH().H
+		if d := min(abs(hb-ha), 360-abs(hb-ha)); d > 10 {
+			t.Errorf("%s hue moved %.1f° (%s → %s)", field, d, before, after)
+		}
+	}
+
+	// The emitted colors.css round trips through the loader
+	colors, err := csscolors.LoadColorList(lp.ColorsCSS())
+	if err != nil {
+		t.Fatal(err)
+	}
+	darkColors, _ := csscolors.LoadColorList(dark.ColorsCSS())
+	if len(colors) != len(darkColors) {
+		t.Errorf("colors.css has %d variables, want %d", len(colors), len(darkColors))
+	}
+	for i, c := range colors {
+		if c != lp.Colors[i] {
+			t.Errorf("colors.css declares --%s: %s, want %s", c.Name, c.Value, lp.Colors[i].Value)
+		}
+	}
+
+	// Named after light, applying the diff to light/colors.css gives the
+	// proposal
+	if err := lp.UseNames(light.ColorsCSS(), light.GetPalette()); err != nil {
+		t.Fatal(err)
+	}
+	lightColors, _ := csscolors.LoadColors(light.ColorsCSS())
+	backgroundVar := ""
+	for name, value := range lightColors {
+		if strings.EqualFold(value, light.GetPalette().Background) {
+			backgroundVar = name
+		}
+	}
+	if got := lp.Colors[slices.IndexFunc(lp.Colors, func(c csscolors.NamedColor) bool {
+		return slices.Contains(lp.Fields[c.Name], "Background")
+	})].Name; got != backgroundVar {
+		t.Errorf("Background is proposed as --%s, want light's --%s", got, backgroundVar)
+	}
+
+	changes, err := lp.Diff(light.ColorsCSS(), light.GetPalette())
+	if err != nil {
+		t.Fatal(err)
+	}
+	applied := maps.Clone(lightColors)
+	for _, c := range changes {
+		if c.Proposed == "" {
+			delete(applied, c.Name)
+		} else {
+			applied[c.Name] = c.Proposed
+		}
+	}
+	proposed, _ := csscolors.LoadColors(lp.ColorsCSS())
+	if !maps.EqualFunc(applied, proposed, strings.EqualFold) {
+		t.Error("applying the diff to light/colors.css doesn't give the proposal")
+	}
+
+	var report bytes.Buffer
+	WriteDiff(&report, changes, 0)
+	for _, c := range changes {
+		if !strings.Contains(report.String(), "--"+c.Name+":") {
+			t.Errorf("diff is missing --%s", c.Name)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package derive
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+)
+
+// RampOptions describes an evenly spaced ramp. The anchor supplies hue and
+// chroma; lightness runs from MinL to MaxL in Count equal OKLCH steps.
+type RampOptions struct {
+	Name       string
+	Anchor     colormath.Color
+	Count      int
+	MinL, MaxL float64
+}
+
+// RampStep is one generated color of a ramp
+type RampStep struct {
+	Name  string
+	Color colormath.Color
+}
+
+// GenerateRamp returns the ramp from darkest to lightest. Steps are named
+// after their darkness, Name plus 1000 × (1 − L) rounded to tens, so gray820
+// sits at L 0.18 whatever the count. Ramps too fine for tens are named to
+// the unit (gray143); one too fine even for that is an error, as are fewer
+// than one step and a MinL not below MaxL.
+func GenerateRamp(opts RampOptions) ([]RampStep, error) {
+	if opts.Count < 1 {
+		return nil, fmt.Errorf("ramp %s: count %d, want at least 1", opts.Name, opts.Count)
+	}
+	if opts.MinL >= opts.MaxL {
+		return nil, fmt.Errorf("ramp %s: min L %.3f must be below max L %.3f", opts.Name, opts.MinL, opts.MaxL)
+	}
+
+	anchor := opts.Anchor.OKLCH()
+	ls := make([]float64, opts.Count)
+	for i := range ls {
+		ls[i] = opts.MinL
+		if opts.Count > 1 {
+			ls[i] += float64(i) * (opts.MaxL - opts.MinL) / float64(opts.Count-1)
+		}
+	}
+
+	for _, round := range []float64{10, 1} {
+		steps := make([]RampStep, 0, opts.Count)
+		seen := map[string]bool{}
+		for _, l := range ls {
+			name := rampStepName(opts.Name, l, round)
+			if seen[name] {
+				break
+			}
+			seen[name] = true
+			steps = append(steps, RampStep{Name: name, Color: colormath.OKLCH{L: l, C: anchor.C, H: anchor.H}.Color(1)})
+		}
+		if len(steps) == opts.Count {
+			return steps, nil
+		}
+	}
+	return nil, fmt.Errorf("ramp %s: %d steps between L %.3f and %.3f are too close to name apart", opts.Name, opts.Count, opts.MinL, opts.MaxL)
+}
+
+// rampStepName names the step at lightness l, its darkness in thousandths
+// rounded to a multiple of round
+func rampStepName(name string, l, round float64) string {
+	return fmt.Sprintf("%s%d", name, int(math.Round((1-l)*1000/round)*round))
+}
+
+// RampCSS formats generated steps as colors.css declarations, preceded by
+// the @ramp comment csscolors.LoadRamps reads
+func RampCSS(name string, steps []RampStep) []byte {
+	var b strings.Builder
+	names := make([]string, len(steps))
+	for i, s := range steps {
+		names[i] = s.Name
+	}
+	fmt.Fprintf(&b, "  /* @ramp %s: %s */\n", name, strings.Join(names, " "))
+	for _, s := range steps {
+		fmt.Fprintf(&b, "  --%s: %s; /* L %.3f */\n", s.Name, s.Color.Hex(), s.Color.OKLCH().L)
+	}
+	return []byte(b.String())
+}
+
+// RampDeviation compares one hand-picked step with where an evenly spaced
+// ramp over the same range would put it
+type RampDeviation struct {
+	Name   string
+	Value  string
+	L      float64
+	EvenL  float64
+	DeltaE float64
+}
+
+// RampDeviations measures a ramp declared in colors.css against an even
+// ramp with the same count between its darkest and lightest steps. DeltaE
+// is between each step and the same color moved to the even lightness.
+func RampDeviations(r csscolors.Ramp, colors csscolors.ColorMap) ([]RampDeviation, error) {
+	devs := make([]RampDeviation, 0, len(r.Steps))
+	var ls []float64
+	var cs []colormath.Color
+	for _, step := range r.Steps {
+		c, err := colormath.ParseHex(colors[step])
+		if err != nil {
+			return nil, fmt.Errorf("ramp %s: %s: %w", r.Name, step, err)
+		}
+		cs = append(cs, c.Opaque())
+		ls = append(ls, c.OKLCH().L)
+	}
+
+	for i, step := range r.Steps {
+		even := ls[0]
+		if len(ls) > 1 {
+			even += float64(i) * (ls[len(ls)-1] - ls[0]) / float64(len(ls)-1)
+		}
+		moved := cs[i].Map(func(o colormath.OKLCH) colormath.OKLCH {
+			o.L = even
+			return o
+		})
+		devs = append(devs, RampDeviation{
+			Name:   step,
+			Value:  colors[step],
+			L:      ls[i],
+			EvenL:  even,
+			DeltaE: colormath.DeltaE(cs[i], moved),
+		})
+	}
+	return devs, nil
+}
+
+// WriteRampReport prints a ramp's deviations, marking steps that are out of
+// lightness order
+func WriteRampReport(w io.Writer, name string, devs []RampDeviation) {
+	fmt.Fprintf(w, "Ramp %s (%d steps, darkest first)\n", name, len(devs))
+	fmt.Fprintf(w, "  %-14s %-10s %7s %7s %7s %7s\n", "step", "value", "L", "even L", "ΔL", "ΔE")
+	for i, d := range devs {
+		mark := ""
+		if i > 0 && d.L <= devs[i-1].L {
+			mark = "  not lighter than " + devs[i-1].Name
+		}
+		fmt.Fprintf(w, "  %-14s %-10s %7.3f %7.3f %+7.3f %7.2f%s\n", d.Name, d.Value, d.L, d.EvenL, d.L-d.EvenL, d.DeltaE, mark)
+	}
+}
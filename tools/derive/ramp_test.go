@@ -0,0 +1,108 @@
+package derive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/dark"
+)
+
+func TestGenerateRamp(t *testing.T) {
+	anchor := colormath.MustParseHex("#647c9bff")
+	steps, err := GenerateRamp(RampOptions{Name: "gray", Anchor: anchor, Count: 6, MinL: 0.2, MaxL: 0.95})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 6 {
+		t.Fatalf("got %d steps, want 6", len(steps))
+	}
+
+	seen := map[string]bool{}
+	for i, s := range steps {
+		want := 0.2 + float64(i)*0.15
+		if l := s.Color.OKLCH().L; math.Abs(l-want) > 0.01 {
+			t.Errorf("%s: L = %.3f, want %.3f", s.Name, l, want)
+		}
+		if seen[s.Name] {
+			t.Errorf("duplicate step name %s", s.Name)
+		}
+		seen[s.Name] = true
+	}
+	if steps[0].Name != "gray800" || steps[5].Name != "gray50" {
+		t.Errorf("names = %s..%s, want gray800..gray50", steps[0].Name, steps[5].Name)
+	}
+
+	// The generated CSS declares its own ramp
+	ramps, err := csscolors.LoadRamps(append(append([]byte(":root {\n"), RampCSS("gray", steps)...), '}'))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ramps) != 1 || len(ramps[0].Steps) != 6 {
+		t.Fatalf("ramps = %v", ramps)
+	}
+}
+
+func TestGenerateRampFine(t *testing.T) {
+	// Steps closer than 0.01 L would share a name rounded to tens
+	anchor := colormath.MustParseHex("#14191fff")
+	steps, err := GenerateRamp(RampOptions{Name: "gray", Anchor: anchor, Count: 80, MinL: 0.2, MaxL: 0.95})
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[string]bool{}
+	for _, s := range steps {
+		if seen[s.Name] {
+			t.Errorf("duplicate step name %s", s.Name)
+		}
+		seen[s.Name] = true
+	}
+	if steps[0].Name != "gray800" || steps[1].Name != "gray791" {
+		t.Errorf("names start %s %s, want gray800 gray791", steps[0].Name, steps[1].Name)
+	}
+}
+
+func TestGenerateRampErrors(t *testing.T) {
+	anchor := colormath.MustParseHex("#647c9bff")
+	for _, opts := range []RampOptions{
+		{Name: "gray", Anchor: anchor, Count: 0, MinL: 0.2, MaxL: 0.95},
+		{Name: "gray", Anchor: anchor, Count: 11, MinL: 0.9, MaxL: 0.2},
+		{Name: "gray", Anchor: anchor, Count: 11, MinL: 0.5, MaxL: 0.5},
+		{Name: "gray", Anchor: anchor, Count: 2000, MinL: 0.2, MaxL: 0.95},
+	} {
+		if _, err := GenerateRamp(opts); err == nil {
+			t.Errorf("GenerateRamp(count %d, L %.2f..%.2f) succeeded", opts.Count, opts.MinL, opts.MaxL)
+		}
+	}
+}
+
+func TestRampDeviations(t *testing.T) {
+	ramps, err := csscolors.LoadRamps(dark.ColorsCSS())
+	if err != nil {
+		t.Fatal(err)
+	}
+	gray, ok := csscolors.RampOf(ramps, "gray800")
+	if !ok {
+		t.Fatal("dark colors.css declares no ramp containing gray800")
+	}
+	if next, _ := gray.Lighter("gray800"); next != "gray750" {
+		t.Errorf("one step lighter than gray800 = %q, want gray750", next)
+	}
+	if _, ok := gray.Lighter(gray.Steps[len(gray.Steps)-1]); ok {
+		t.Error("the lightest step has a lighter step")
+	}
+
+	colors, err := csscolors.LoadColors(dark.ColorsCSS())
+	if err != nil {
+		t.Fatal(err)
+	}
+	devs, err := RampDeviations(gray, colors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, last := devs[0], devs[len(devs)-1]
+	if first.DeltaE > 0.01 || last.DeltaE > 0.01 {
+		t.Errorf("ramp ends deviate: %.2f, %.2f", first.DeltaE, last.DeltaE)
+	}
+}
@@ -0,0 +1,242 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+// AnnotateSource is a variant and the files its palette was loaded from
+type AnnotateSource struct {
+	Variant palette.ThemeVariant
+	// ColorsCSS is the colors.css the palette's colors come from
+	ColorsCSS []byte
+	// PaletteGo is the palette.go assigning them to fields
+	PaletteGo []byte
+}
+
+// origins explains where the style colors of one variant come from
+type origins struct {
+	byValue map[string][]string // lowercase color → colors.css variables
+	usages  map[string][]string // colors.css variable → palette fields
+}
+
+func newOrigins(src AnnotateSource) (*origins, error) {
+	colors, err := csscolors.LoadColorList(src.ColorsCSS)
+	if err != nil {
+		return nil, err
+	}
+	usages, err := csscolors.Usages(src.PaletteGo, colors)
+	if err != nil {
+		return nil, err
+	}
+	o := &origins{byValue: map[string][]string{}, usages: usages}
+	for _, c := range colors {
+		value := strings.ToLower(c.Value)
+		o.byValue[value] = append(o.byValue[value], c.Name)
+	}
+	return o, nil
+}
+
+// variable returns the colors.css variable holding value, preferring one
+// palette.go assigns to field when several share the color
+func (o *origins) variable(value, field string) string {
+	names := o.byValue[strings.ToLower(value)]
+	if len(names) == 0 {
+		return ""
+	}
+	for _, name := range names {
+		for _, use := range o.usages[name] {
+			if use, _, _ := strings.Cut(use, " ("); use == field && field != "" {
+				return name
+			}
+		}
+	}
+	return names[0]
+}
+
+// comment returns the annotation for the style color at key, e.g.
+// "← ForegroundMuted ← --gray500", or "" when nothing is known about it
+func (o *origins) comment(key, value string) string {
+	source := palette.StyleSources()[key]
+	field := source.Field
+	var parts []string
+	switch {
+	case field != "":
+		parts = append(parts, field)
+	case source.Generator != "":
+		parts = append(parts, source.Generator)
+	}
+	if name := o.variable(value, field); name != "" {
+		parts = append(parts, "--"+name)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "← " + strings.Join(parts, " ← ")
+}
+
+// styleKey turns a JSON path inside a theme's "style" object into the
+// palette.StyleSources key, or "" for non-color values
+func styleKey(path []string) string {
+	switch {
+	case len(path) == 1:
+		return path[0]
+	case len(path) == 3 && path[0] == "players":
+		return strings.Join(path, "/")
+	case len(path) == 3 && path[0] == "syntax" && path[2] == "color":
+		return "syntax/" + path[1]
+	}
+	return ""
+}
+
+// Annotate returns a JSONC copy of a generated theme family with a comment
+// after every style color naming the TronThemePalette field and colors.css
+// variable it came from:
+//
+//	"text.muted": "#647c9bff",  // ← ForegroundMuted ← --gray500
+//
+// The field comes from palette.StyleSources. themeJSON must be the family
+// generated from the variants in srcs, in order. Apart from the comments
+// the output is byte for byte the same as themeJSON.
+func Annotate(themeJSON []byte, srcs []AnnotateSource) ([]byte, error) {
+	all := make([]*origins, len(srcs))
+	for i, src := range srcs {
+		o, err := newOrigins(src)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", src.Variant.Name, err)
+		}
+		all[i] = o
+	}
+
+	// comments holds the annotation for the line ending at each offset
+	comments := map[int]string{}
+	annotate := func(path []string, value string, offset int) error {
+		if len(path) < 4 || path[0] != "themes" || path[2] != "style" {
+			return nil
+		}
+		var i int
+		fmt.Sscan(path[1], &i)
+		if i >= len(all) {
+			return fmt.Errorf("theme %d has no variant", i)
+		}
+		key := styleKey(path[3:])
+		if key == "" || !strings.HasPrefix(value, "#") {
+			return nil
+		}
+		if c := all[i].comment(key, value); c != "" {
+			end := bytes.IndexByte(themeJSON[offset:], '\n')
+			if end < 0 {
+				end = len(themeJSON) - offset
+			}
+			comments[offset+end] = c
+		}
+		return nil
+	}
+
+	if err := walkJSON(themeJSON, annotate); err != nil {
+		return nil, err
+	}
+
+	ends := make([]int, 0, len(comments))
+	for end := range comments {
+		ends = append(ends, end)
+	}
+	sort.Ints(ends)
+
+	var b bytes.Buffer
+	b.WriteString("// Annotated copy of the generated theme: each color is followed by the\n")
+	b.WriteString("// TronThemePalette field and colors.css variable it comes from. Zed reads\n")
+	b.WriteString("// the plain JSON; this file is for tracing colors only.\n")
+	last := 0
+	for _, end := range ends {
+		b.Write(themeJSON[last:end])
+		b.WriteString("  // ")
+		b.WriteString(comments[end])
+		last = end
+	}
+	b.Write(themeJSON[last:])
+	return b.Bytes(), nil
+}
+
+// walkJSON calls fn with the path and input offset of every string value
+// in data
+func walkJSON(data []byte, fn func(path []string, value string, offset int) error) error {
+	type frame struct {
+		array     bool
+		index     int
+		key       string
+		expectKey bool
+	}
+	var stack []*frame
+
+	// elem returns the path element of the value being read in frame f
+	elem := func(f *frame) string {
+		if f.array {
+			return fmt.Sprint(f.index)
+		}
+		return f.key
+	}
+	current := func() []string {
+		p := make([]string, len(stack))
+		for i, f := range stack {
+			p[i] = elem(f)
+		}
+		return p
+	}
+	// next moves the innermost frame past the value just read
+	next := func() {
+		if len(stack) == 0 {
+			return
+		}
+		f := stack[len(stack)-1]
+		if f.array {
+			f.index++
+		} else {
+			f.expectKey = true
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(stack) > 0 {
+			if f := stack[len(stack)-1]; !f.array && f.expectKey {
+				if key, ok := tok.(string); ok {
+					f.key, f.expectKey = key, false
+					continue
+				}
+			}
+		}
+		switch tok := tok.(type) {
+		case json.Delim:
+			switch tok {
+			case '{', '[':
+				stack = append(stack, &frame{array: tok == '[', expectKey: tok == '{'})
+			default:
+				stack = stack[:len(stack)-1]
+				next()
+			}
+		case string:
+			if err := fn(current(), tok, int(dec.InputOffset())); err != nil {
+				return err
+			}
+			next()
+		default:
+			next()
+		}
+	}
+}
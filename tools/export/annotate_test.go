@@ -0,0 +1,54 @@
+package export_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/export"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/variants"
+)
+
+var annotation = regexp.MustCompile(`(?m)  // ← .*$`)
+
+func TestAnnotate(t *testing.T) {
+	vs := findGoldenVariants(t)
+	canonical, err := json.MarshalIndent(palette.GenerateTheme("Tron Legacy", "Bret Comnes", vs...), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srcs := make([]export.AnnotateSource, len(vs))
+	for i, v := range vs {
+		srcs[i] = export.AnnotateSource{Variant: v, ColorsCSS: variants.ColorsCSS(v), PaletteGo: variants.PaletteSource(v)}
+	}
+	annotated, err := export.Annotate(canonical, srcs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Removing the header and comments gives back the canonical JSON
+	body := annotated[bytes.IndexByte(annotated, '{'):]
+	if stripped := annotation.ReplaceAll(body, nil); !bytes.Equal(stripped, canonical) {
+		t.Error("annotated theme differs from the canonical JSON once comments are removed")
+	}
+
+	for _, want := range []string{
+		`"text.muted": "#647c9bff",  // ← ForegroundMuted ← --gray500`,
+		`"background": "#14191fff",  // ← Background ← --gray900`,
+		`"color": "#586676ff",  // ← Comment ← --gray550`,
+	} {
+		if !bytes.Contains(annotated, []byte(want)) {
+			t.Errorf("missing annotation %s", want)
+		}
+	}
+
+	// Every color of the shipped palettes comes from a colors.css variable
+	for _, line := range strings.Split(string(body), "\n") {
+		if strings.Contains(line, `": "#`) && !strings.Contains(line, "// ← ") {
+			t.Errorf("unannotated color: %s", strings.TrimSpace(line))
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// Package export converts theme variants into formats for other tools:
+// design tokens, web stylesheets, syntax highlighters and terminals.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+func init() {
+	Register(NewExporter("dtcg", ".tokens.json", func(v palette.ThemeVariant, p palette.TronThemePalette, _ *palette.ThemeStyle, colorsCSS []byte) ([]byte, error) {
+		v.Palette = p
+		return DTCG(v, colorsCSS)
+	}))
+}
+
+// Token is a W3C Design Tokens Community Group token. Value is either a
+// color or an alias such as "{base.gray900}".
+type Token struct {
+	Value string `json:"$value"`
+}
+
+// group is a DTCG group: nested groups and tokens keyed by name
+type group map[string]any
+
+// Tiers holds the three token tiers of one variant, mirroring the
+// colors.css → TronThemePalette → ThemeStyle layers. Each tier aliases the
+// one below it wherever a value matches, so changing a raw color flows
+// through every token that uses it.
+type Tiers struct {
+	Base      group
+	Semantic  group
+	Component group
+}
+
+// baseAliases maps each color to the first colors.css variable holding it
+type baseAliases map[string]string
+
+func (b baseAliases) alias(value string) string {
+	if name, ok := b[strings.ToLower(value)]; ok {
+		return "{base." + name + "}"
+	}
+	return value
+}
+
+// lowerCamel turns a Go field name into a token name: EditorBackground →
+// editorBackground, UIAccent → uiAccent
+func lowerCamel(s string) string {
+	r := []rune(s)
+	for i := 0; i < len(r) && unicode.IsUpper(r[i]); i++ {
+		// Keep the capital that starts the next word after an acronym
+		if i > 0 && i+1 < len(r) && unicode.IsLower(r[i+1]) {
+			break
+		}
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
+
+// NewTiers builds the token tiers for a variant from the colors.css its
+// palette was loaded from
+func NewTiers(v palette.ThemeVariant, colorsCSS []byte) (Tiers, error) {
+	colors, err := csscolors.LoadColorList(colorsCSS)
+	if err != nil {
+		return Tiers{}, err
+	}
+
+	t := Tiers{Base: group{"$type": "color"}, Semantic: group{"$type": "color"}, Component: group{"$type": "color"}}
+	base := baseAliases{}
+	for _, c := range colors {
+		if !strings.HasPrefix(c.Value, "#") {
+			continue
+		}
+		t.Base[c.Name] = Token{Value: strings.ToLower(c.Value)}
+		if _, ok := base[strings.ToLower(c.Value)]; !ok {
+			base[strings.ToLower(c.Value)] = c.Name
+		}
+	}
+
+	for _, field := range palette.ColorFields() {
+		if value, _ := v.Palette.Get(field); value != "" {
+			t.Semantic[lowerCamel(field)] = Token{Value: base.alias(value)}
+		}
+	}
+	accents := group{}
+	for i, accent := range v.Palette.Accents {
+		accents[fmt.Sprint(i)] = Token{Value: base.alias(accent)}
+	}
+	t.Semantic["accents"] = accents
+
+	styled, err := styleColors(palette.GenerateThemeStyle(v.Name, v.Appearance, v.Palette).Style)
+	if err != nil {
+		return Tiers{}, err
+	}
+	sources := palette.StyleSources()
+	for _, c := range styled {
+		value := base.alias(c.value)
+		if field := sources[c.key()].Field; field != "" {
+			value = "{semantic." + lowerCamel(field) + "}"
+		}
+		c.set(t.Component, Token{Value: value})
+	}
+	return t, nil
+}
+
+// styleColor is one color of a generated style and its JSON path in the
+// style, e.g. ["players", "0", "cursor"]
+type styleColor struct {
+	path  []string
+	value string
+}
+
+// key is the color's palette.StyleSources key
+func (c styleColor) key() string {
+	return strings.Join(c.path, "/")
+}
+
+// set stores a token at the color's path, creating groups on the way
+func (c styleColor) set(g group, token Token) {
+	for _, name := range c.path[:len(c.path)-1] {
+		child, ok := g[tokenName(name)].(group)
+		if !ok {
+			child = group{}
+			g[tokenName(name)] = child
+		}
+		g = child
+	}
+	g[tokenName(c.path[len(c.path)-1])] = token
+}
+
+// styleColors lists every color in a style: the flat keys, each player's
+// colors and each syntax style's color
+func styleColors(style *palette.ThemeStyle) ([]styleColor, error) {
+	var flat map[string]any
+	data, err := json.Marshal(style)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &flat); err != nil {
+		return nil, err
+	}
+
+	var colors []styleColor
+	add := func(value any, path ...string) {
+		if s, ok := value.(string); ok && strings.HasPrefix(s, "#") {
+			colors = append(colors, styleColor{path: path, value: s})
+		}
+	}
+	for key, value := range flat {
+		switch value := value.(type) {
+		case []any: // players
+			for i, player := range value {
+				for k, c := range player.(map[string]any) {
+					add(c, key, fmt.Sprint(i), k)
+				}
+			}
+		case map[string]any: // syntax
+			for k, st := range value {
+				add(st.(map[string]any)["color"], key, k)
+			}
+		default:
+			add(value, key)
+		}
+	}
+	return colors, nil
+}
+
+// tokenName makes a Zed style key usable as a token name. DTCG reserves
+// "." for alias paths, so editor.background becomes editor-background.
+func tokenName(key string) string {
+	return strings.ReplaceAll(key, ".", "-")
+}
+
+// DTCG returns the variant as a single DTCG JSON document with base,
+// semantic and component groups
+func DTCG(v palette.ThemeVariant, colorsCSS []byte) ([]byte, error) {
+	t, err := NewTiers(v, colorsCSS)
+	if err != nil {
+		return nil, err
+	}
+	doc := group{
+		"$description": fmt.Sprintf("%s (%s) design tokens", v.Name, v.Appearance),
+		"base":         t.Base,
+		"semantic":     t.Semantic,
+		"component":    t.Component,
+	}
+	return marshal(doc)
+}
+
+func marshal(doc any) ([]byte, error) {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// StyleDictionary lays out a variant as a Style Dictionary project: one
+// token file per tier under tokens/ and a config.json building CSS, SCSS
+// and JavaScript outputs. Paths are relative to the project directory.
+func StyleDictionary(v palette.ThemeVariant, colorsCSS []byte) (map[string][]byte, error) {
+	t, err := NewTiers(v, colorsCSS)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{}
+	for name, tier := range map[string]group{"base": t.Base, "semantic": t.Semantic, "component": t.Component} {
+		data, err := marshal(group{name: tier})
+		if err != nil {
+			return nil, err
+		}
+		files["tokens/"+name+".json"] = data
+	}
+
+	platform := func(transformGroup, buildPath, destination, format string) group {
+		return group{
+			"transformGroup": transformGroup,
+			"buildPath":      buildPath,
+			"files":          []group{{"destination": destination, "format": format}},
+		}
+	}
+	config, err := marshal(group{
+		"source": []string{"tokens/**/*.json"},
+		"platforms": group{
+			"css":  platform("css", "build/css/", v.Slug()+".css", "css/variables"),
+			"scss": platform("scss", "build/scss/", "_"+v.Slug()+".scss", "scss/variables"),
+			"js":   platform("js", "build/js/", v.Slug()+".js", "javascript/es6"),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	files["config.json"] = config
+	return files, nil
+}
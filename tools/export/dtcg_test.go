@@ -0,0 +1,116 @@
+package export
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/variants"
+)
+
+var aliasPattern = regexp.MustCompile(`^\{([^{}]+)\}$`)
+
+// tokens flattens a DTCG document into token path → $value
+func tokens(t *testing.T, doc map[string]any, prefix string, out map[string]string) {
+	t.Helper()
+	for name, value := range doc {
+		if strings.HasPrefix(name, "$") {
+			continue
+		}
+		node, ok := value.(map[string]any)
+		if !ok {
+			t.Fatalf("%s%s is not a group or token", prefix, name)
+		}
+		if v, ok := node["$value"].(string); ok {
+			out[prefix+name] = v
+			continue
+		}
+		tokens(t, node, prefix+name+".", out)
+	}
+}
+
+// resolve follows aliases down to a color
+func resolve(all map[string]string, value string) (string, bool) {
+	for i := 0; i < 10; i++ {
+		m := aliasPattern.FindStringSubmatch(value)
+		if m == nil {
+			return value, true
+		}
+		var ok bool
+		if value, ok = all[m[1]]; !ok {
+			return "", false
+		}
+	}
+	return "", false
+}
+
+func TestDTCG(t *testing.T) {
+	for _, v := range variants.All() {
+		data, err := DTCG(v, variants.ColorsCSS(v))
+		if err != nil {
+			t.Fatalf("%s: %v", v.Name, err)
+		}
+		var doc map[string]any
+		if err := json.Unmarshal(data, &doc); err != nil {
+			t.Fatalf("%s: %v", v.Name, err)
+		}
+		all := map[string]string{}
+		tokens(t, doc, "", all)
+
+		style := palette.GenerateThemeStyle(v.Name, v.Appearance, v.Palette).Style
+		checks := map[string]string{
+			"component.editor-background": style.EditorBackground,
+			"component.text":              style.Text,
+			"component.players.0.cursor":  style.Players[0].Cursor,
+			"component.syntax.keyword":    style.Syntax.Keyword.Color,
+			"semantic.accents.0":          v.Palette.Accents[0],
+		}
+		for path, want := range checks {
+			got, ok := resolve(all, all[path])
+			if !ok || !strings.EqualFold(got, want) {
+				t.Errorf("%s: %s resolves to %q, want %s", v.Name, path, got, want)
+			}
+		}
+
+		colors, err := styleColors(style)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Token paths of the colors the generator computes
+		generated := map[string]bool{}
+		for _, c := range colors {
+			var names []string
+			for _, name := range c.path {
+				names = append(names, tokenName(name))
+			}
+			generated["component."+strings.Join(names, ".")] = palette.StyleSources()[c.key()].Generator != ""
+		}
+		for path, value := range all {
+			if _, ok := resolve(all, value); !ok {
+				t.Errorf("%s: %s has dangling alias %s", v.Name, path, value)
+			}
+			// The hand-written palettes are built entirely from colors.css,
+			// so nothing above the base tier repeats a raw value except the
+			// player colors and status tiers the generator derives
+			if !strings.HasPrefix(path, "base.") && !generated[path] && !aliasPattern.MatchString(value) && (v.Name == "Tron Legacy" || v.Name == "Tron Legacy Light") {
+				t.Errorf("%s: %s duplicates value %s instead of aliasing", v.Name, path, value)
+			}
+		}
+	}
+}
+
+func TestStyleDictionary(t *testing.T) {
+	v := variants.All()[0]
+	files, err := StyleDictionary(v, variants.ColorsCSS(v))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"config.json", "tokens/base.json", "tokens/semantic.json", "tokens/component.json"} {
+		var doc map[string]any
+		if err := json.Unmarshal(files[name], &doc); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package export
+
+import (
+	"sort"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+// Exporter writes one theme variant in another tool's format. Exporters
+// register themselves with Register, usually from an init function, so a
+// new format is a new file or package and nothing else changes.
+type Exporter interface {
+	// Name selects the exporter on the command line, e.g. "chroma"
+	Name() string
+	// Extension is appended to the variant slug to name the output file,
+	// e.g. ".chroma.xml"
+	Extension() string
+	// Export renders the variant. p is the variant's palette, style the Zed
+	// style generated from it and colorsCSS the colors.css the palette was
+	// loaded from, for formats that refer to its variables.
+	Export(v palette.ThemeVariant, p palette.TronThemePalette, style *palette.ThemeStyle, colorsCSS []byte) ([]byte, error)
+}
+
+// ExportFunc is the signature of Exporter.Export
+type ExportFunc func(v palette.ThemeVariant, p palette.TronThemePalette, style *palette.ThemeStyle, colorsCSS []byte) ([]byte, error)
+
+type funcExporter struct {
+	name, ext string
+	fn        ExportFunc
+}
+
+func (e funcExporter) Name() string      { return e.name }
+func (e funcExporter) Extension() string { return e.ext }
+func (e funcExporter) Export(v palette.ThemeVariant, p palette.TronThemePalette, style *palette.ThemeStyle, colorsCSS []byte) ([]byte, error) {
+	return e.fn(v, p, style, colorsCSS)
+}
+
+// NewExporter makes an Exporter from a function
+func NewExporter(name, ext string, fn ExportFunc) Exporter {
+	return funcExporter{name: name, ext: ext, fn: fn}
+}
+
+var exporters = map[string]Exporter{}
+
+// Register adds an exporter to the registry. Names must be unique.
+func Register(e Exporter) {
+	if _, ok := exporters[e.Name()]; ok {
+		panic("export: duplicate exporter " + e.Name())
+	}
+	exporters[e.Name()] = e
+}
+
+// Lookup returns the exporter registered under name
+func Lookup(name string) (Exporter, bool) {
+	e, ok := exporters[name]
+	return e, ok
+}
+
+// Exporters returns every registered exporter sorted by name
+func Exporters() []Exporter {
+	all := make([]Exporter, 0, len(exporters))
+	for _, e := range exporters {
+		all = append(all, e)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name() < all[j].Name() })
+	return all
+}
+
+// Run exports a variant, generating its Zed style first. colorsCSS is the
+// colors.css its palette was loaded from.
+func Run(e Exporter, v palette.ThemeVariant, colorsCSS []byte) ([]byte, error) {
+	style := palette.GenerateThemeStyle(v.Name, v.Appearance, v.Palette).Style
+	return e.Export(v, v.Palette, style, colorsCSS)
+}
@@ -0,0 +1,55 @@
+// Package exporttest is a golden-file harness for export.Exporter
+// implementations. Run the tests with -update to rewrite the golden files
+// after an intended change.
+package exporttest
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/export"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/variants"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+// Golden exports each variant with e and compares the result with
+// dir/<slug><extension>
+func Golden(t *testing.T, e export.Exporter, dir string, vs ...palette.ThemeVariant) {
+	t.Helper()
+	for _, v := range vs {
+		got, err := export.Run(e, v, variants.ColorsCSS(v))
+		if err != nil {
+			t.Errorf("%s %s: %v", e.Name(), v.Name, err)
+			continue
+		}
+		Compare(t, filepath.Join(dir, v.Slug()+e.Extension()), got)
+	}
+}
+
+// Compare checks got against the golden file at path, or rewrites the file
+// with -update
+func Compare(t *testing.T, path string, got []byte) {
+	t.Helper()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("%v (run the test with -update to create it)", err)
+		return
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s differs from the generated output; run the test with -update if the change is intended", path)
+	}
+}
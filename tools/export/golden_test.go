@@ -0,0 +1,45 @@
+package export_test
+
+import (
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/export"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/export/exporttest"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/variants"
+)
+
+// goldenVariants covers dark, light and translucent colors
+var goldenVariants = []string{"Tron Legacy", "Tron Legacy Light Frosted"}
+
+func findGoldenVariants(t *testing.T) []palette.ThemeVariant {
+	t.Helper()
+	var vs []palette.ThemeVariant
+	for _, name := range goldenVariants {
+		v, ok := variants.Find(name)
+		if !ok {
+			t.Fatalf("no variant %s", name)
+		}
+		vs = append(vs, v)
+	}
+	return vs
+}
+
+func TestExportersGolden(t *testing.T) {
+	vs := findGoldenVariants(t)
+	for _, e := range export.Exporters() {
+		t.Run(e.Name(), func(t *testing.T) {
+			exporttest.Golden(t, e, "testdata", vs...)
+		})
+	}
+}
+
+func TestExportersRunOnAllVariants(t *testing.T) {
+	for _, e := range export.Exporters() {
+		for _, v := range variants.All() {
+			if out, err := export.Run(e, v, variants.ColorsCSS(v)); err != nil || len(out) == 0 {
+				t.Errorf("%s %s: %d bytes, %v", e.Name(), v.Name, len(out), err)
+			}
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+func init() {
+	Register(NewExporter("chroma", ".chroma.xml", chroma))
+	Register(NewExporter("pygments", ".pygments.py", pygments))
+	Register(NewExporter("hljs", ".hljs.css", highlightJS))
+}
+
+// tokenMapping ties a Zed syntax highlight to the Pygments tokens and
+// highlight.js classes that mean the same thing. Chroma token names are
+// the Pygments ones without dots.
+type tokenMapping struct {
+	zed      string
+	pygments []string
+	hljs     []string
+}
+
+var tokenMappings = []tokenMapping{
+	{"comment", []string{"Comment"}, []string{"hljs-comment", "hljs-quote"}},
+	{"comment.doc", []string{"String.Doc"}, []string{"hljs-doctag"}},
+	{"preproc", []string{"Comment.Preproc"}, []string{"hljs-meta"}},
+	{"keyword", []string{"Keyword"}, []string{"hljs-keyword"}},
+	{"boolean", []string{"Keyword.Constant"}, []string{"hljs-literal"}},
+	{"type", []string{"Keyword.Type"}, []string{"hljs-type"}},
+	{"operator", []string{"Operator"}, []string{"hljs-operator"}},
+	{"punctuation", []string{"Punctuation"}, []string{"hljs-punctuation"}},
+	{"constant", []string{"Name.Constant"}, []string{"hljs-variable.constant_"}},
+	{"function", []string{"Name.Function"}, []string{"hljs-title.function_"}},
+	{"function.builtin", []string{"Name.Builtin"}, []string{"hljs-built_in"}},
+	{"constructor", []string{"Name.Class"}, []string{"hljs-title.class_"}},
+	{"tag", []string{"Name.Tag"}, []string{"hljs-name", "hljs-selector-tag"}},
+	{"attribute", []string{"Name.Attribute"}, []string{"hljs-attr", "hljs-attribute"}},
+	{"property", []string{"Name.Property"}, []string{"hljs-property"}},
+	{"variable", []string{"Name.Variable"}, []string{"hljs-variable"}},
+	{"variable.special", []string{"Name.Builtin.Pseudo"}, []string{"hljs-variable.language_"}},
+	{"namespace", []string{"Name.Namespace"}, nil},
+	{"label", []string{"Name.Label", "Name.Decorator"}, nil},
+	{"selector.pseudo", nil, []string{"hljs-selector-pseudo"}},
+	{"string", []string{"String"}, []string{"hljs-string"}},
+	{"string.escape", []string{"String.Escape"}, []string{"hljs-char.escape_"}},
+	{"string.regex", []string{"String.Regex"}, []string{"hljs-regexp"}},
+	{"string.special.symbol", []string{"String.Symbol"}, []string{"hljs-symbol"}},
+	{"embedded", []string{"String.Interpol"}, []string{"hljs-subst"}},
+	{"number", []string{"Number"}, []string{"hljs-number"}},
+	{"title", []string{"Generic.Heading"}, []string{"hljs-section"}},
+	{"emphasis", []string{"Generic.Emph"}, []string{"hljs-emphasis"}},
+	{"emphasis.strong", []string{"Generic.Strong"}, []string{"hljs-strong"}},
+	{"link_uri", nil, []string{"hljs-link"}},
+	{"punctuation.list_marker", nil, []string{"hljs-bullet"}},
+	{"diff.plus", []string{"Generic.Inserted"}, []string{"hljs-addition"}},
+	{"diff.minus", []string{"Generic.Deleted"}, []string{"hljs-deletion"}},
+}
+
+// highlightStyle is a syntax style reduced to what highlighters support:
+// an opaque color plus italic and bold
+type highlightStyle struct {
+	color  string
+	italic bool
+	bold   bool
+}
+
+// highlightTheme holds everything the highlighter exporters need from a
+// variant, with translucent colors flattened over the editor background
+// since none of the formats support alpha reliably
+type highlightTheme struct {
+	name       string
+	background string
+	foreground string
+	lineNumber string
+	highlight  string
+	styles     map[string]highlightStyle
+}
+
+func newHighlightTheme(v palette.ThemeVariant, style *palette.ThemeStyle) (highlightTheme, error) {
+	bg, err := colormath.ParseHex(style.EditorBackground)
+	if err != nil {
+		return highlightTheme{}, fmt.Errorf("editor.background: %w", err)
+	}
+	bg = bg.Over(palette.Desktop(v.Appearance))
+	flatten := func(hex string) (string, error) {
+		c, err := colormath.ParseHex(hex)
+		if err != nil {
+			return "", err
+		}
+		return c.Over(bg).Hex()[:7], nil
+	}
+
+	t := highlightTheme{name: v.Name, background: bg.Hex()[:7], styles: map[string]highlightStyle{}}
+	for _, f := range []struct {
+		dst *string
+		hex string
+	}{{&t.foreground, style.EditorForeground}, {&t.lineNumber, style.EditorLineNumber}, {&t.highlight, style.EditorActiveLineBackground}} {
+		if *f.dst, err = flatten(f.hex); err != nil {
+			return highlightTheme{}, err
+		}
+	}
+
+	for _, m := range tokenMappings {
+		st, ok := style.Syntax.Lookup(m.zed)
+		if !ok {
+			return highlightTheme{}, fmt.Errorf("no syntax style %q", m.zed)
+		}
+		c, err := flatten(st.Color)
+		if err != nil {
+			return highlightTheme{}, fmt.Errorf("syntax %s: %w", m.zed, err)
+		}
+		t.styles[m.zed] = highlightStyle{
+			color:  c,
+			italic: st.FontStyle != nil && *st.FontStyle == "italic",
+			bold:   st.FontWeight != nil && *st.FontWeight >= 600,
+		}
+	}
+	return t, nil
+}
+
+// pygmentsStyle formats a style the way Pygments and Chroma both read it
+func (s highlightStyle) pygmentsStyle() string {
+	var parts []string
+	if s.italic {
+		parts = append(parts, "italic")
+	}
+	if s.bold {
+		parts = append(parts, "bold")
+	}
+	return strings.Join(append(parts, s.color), " ")
+}
+
+// chromaToken converts a Pygments token name to Chroma's: Keyword.Type →
+// KeywordType, String.Escape → LiteralStringEscape
+func chromaToken(pygments string) string {
+	if strings.HasPrefix(pygments, "String") || strings.HasPrefix(pygments, "Number") {
+		pygments = "Literal" + pygments
+	}
+	return strings.ReplaceAll(pygments, ".", "")
+}
+
+// chroma returns a Chroma XML style, loadable with styles.NewXMLRegistry
+// or chroma's --style-file
+func chroma(v palette.ThemeVariant, _ palette.TronThemePalette, style *palette.ThemeStyle, _ []byte) ([]byte, error) {
+	t, err := newHighlightTheme(v, style)
+	if err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "<!-- %s Chroma style. Generated by tools/generate-theme.go; do not edit. -->\n", html.EscapeString(t.name))
+	fmt.Fprintf(&b, "<style name=%q>\n", v.Slug())
+	entry := func(token, style string) {
+		fmt.Fprintf(&b, "  <entry type=%q style=%q/>\n", token, style)
+	}
+	entry("Background", fmt.Sprintf("bg:%s %s", t.background, t.foreground))
+	entry("LineHighlight", "bg:"+t.highlight)
+	entry("LineNumbers", t.lineNumber)
+	entry("LineNumbersTable", t.lineNumber)
+	for _, m := range tokenMappings {
+		for _, token := range m.pygments {
+			entry(chromaToken(token), t.styles[m.zed].pygmentsStyle())
+		}
+	}
+	b.WriteString("</style>\n")
+	return []byte(b.String()), nil
+}
+
+// pygments returns a Python module defining a Pygments Style subclass
+func pygments(v palette.ThemeVariant, _ palette.TronThemePalette, style *palette.ThemeStyle, _ []byte) ([]byte, error) {
+	t, err := newHighlightTheme(v, style)
+	if err != nil {
+		return nil, err
+	}
+
+	imports := map[string]bool{"Text": true}
+	for _, m := range tokenMappings {
+		for _, token := range m.pygments {
+			root, _, _ := strings.Cut(token, ".")
+			imports[root] = true
+		}
+	}
+	names := make([]string, 0, len(imports))
+	for name := range imports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\"\"\"%s style for Pygments. Generated by tools/generate-theme.go; do not edit.\"\"\"\n\n", t.name)
+	b.WriteString("from pygments.style import Style\n")
+	fmt.Fprintf(&b, "from pygments.token import %s\n\n\n", strings.Join(names, ", "))
+	fmt.Fprintf(&b, "class %sStyle(Style):\n", className(t.name))
+	fmt.Fprintf(&b, "    name = %q\n", v.Slug())
+	fmt.Fprintf(&b, "    background_color = %q\n", t.background)
+	fmt.Fprintf(&b, "    highlight_color = %q\n", t.highlight)
+	fmt.Fprintf(&b, "    line_number_color = %q\n\n", t.lineNumber)
+	b.WriteString("    styles = {\n")
+	fmt.Fprintf(&b, "        Text: %q,\n", t.foreground)
+	for _, m := range tokenMappings {
+		for _, token := range m.pygments {
+			fmt.Fprintf(&b, "        %s: %q,\n", token, t.styles[m.zed].pygmentsStyle())
+		}
+	}
+	b.WriteString("    }\n")
+	return []byte(b.String()), nil
+}
+
+// className turns "Tron Legacy Light Deuteranopia-friendly" into
+// TronLegacyLightDeuteranopiaFriendly
+func className(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return r == ' ' || r == '-' }) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// highlightJS returns a highlight.js CSS theme
+func highlightJS(v palette.ThemeVariant, _ palette.TronThemePalette, style *palette.ThemeStyle, _ []byte) ([]byte, error) {
+	t, err := newHighlightTheme(v, style)
+	if err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "/* %s highlight.js theme. Generated by tools/generate-theme.go; do not edit. */\n\n", t.name)
+	fmt.Fprintf(&b, ".hljs {\n  color: %s;\n  background: %s;\n}\n", t.foreground, t.background)
+	for _, m := range tokenMappings {
+		if len(m.hljs) == 0 {
+			continue
+		}
+		s := t.styles[m.zed]
+		selectors := make([]string, len(m.hljs))
+		for i, class := range m.hljs {
+			selectors[i] = "." + class
+		}
+		fmt.Fprintf(&b, "\n%s {\n  color: %s;\n", strings.Join(selectors, ",\n"), s.color)
+		if s.italic {
+			b.WriteString("  font-style: italic;\n")
+		}
+		if s.bold {
+			b.WriteString("  font-weight: bold;\n")
+		}
+		b.WriteString("}\n")
+	}
+	return []byte(b.String()), nil
+}
@@ -0,0 +1,44 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/variants"
+)
+
+// mustRun exports a variant with a registered exporter
+func mustRun(t *testing.T, name string, v palette.ThemeVariant) []byte {
+	t.Helper()
+	e, ok := Lookup(name)
+	if !ok {
+		t.Fatalf("no exporter %s", name)
+	}
+	out, err := Run(e, v, variants.ColorsCSS(v))
+	if err != nil {
+		t.Fatalf("%s %s: %v", name, v.Name, err)
+	}
+	return out
+}
+
+func TestHighlighterFontStyles(t *testing.T) {
+	v := variants.All()[0]
+	style := palette.GenerateThemeStyle(v.Name, v.Appearance, v.Palette).Style
+	if style.Syntax.Keyword.FontStyle == nil || *style.Syntax.Keyword.FontStyle != "italic" {
+		t.Skip("keywords are no longer italic")
+	}
+
+	py := mustRun(t, "pygments", v)
+	if !strings.Contains(string(py), `Keyword: "italic #`) {
+		t.Error("Pygments style lost the italic keywords")
+	}
+	css := mustRun(t, "hljs", v)
+	if !strings.Contains(string(css), ".hljs-keyword {\n  color: #267fb5;\n  font-style: italic;\n}") {
+		t.Error("highlight.js theme lost the italic keywords")
+	}
+	chroma := mustRun(t, "chroma", v)
+	if !strings.Contains(string(chroma), `<entry type="NameClass" style="italic bold #`) {
+		t.Error("Chroma style lost the italic bold constructors")
+	}
+}
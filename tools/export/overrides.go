@@ -0,0 +1,166 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+// member is one key of an object marshaled in insertion order
+type member struct {
+	key   string
+	value any
+}
+
+// orderedObject marshals as a JSON object keeping its members' order, so
+// overrides come out in the same order as the theme file
+type orderedObject []member
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(m.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// jsonName returns the JSON key of a struct field
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	return name
+}
+
+// diffStyle returns the keys of changed whose values differ from base, in
+// ThemeStyle order. Syntax styles are compared one by one and nested under
+// "syntax"; players are replaced as a whole. keys lists the changed keys
+// flat, "syntax.comment" for syntax styles.
+func diffStyle(base, changed *palette.ThemeStyle) (diff orderedObject, keys []string) {
+	bv, cv := reflect.ValueOf(*base), reflect.ValueOf(*changed)
+	t := bv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := jsonName(t.Field(i))
+		b, c := bv.Field(i).Interface(), cv.Field(i).Interface()
+		if reflect.DeepEqual(b, c) {
+			continue
+		}
+		if syntax, ok := c.(palette.SyntaxStyles); ok {
+			var styles orderedObject
+			sb, sc := reflect.ValueOf(b), reflect.ValueOf(syntax)
+			for j := 0; j < sc.NumField(); j++ {
+				if !reflect.DeepEqual(sb.Field(j).Interface(), sc.Field(j).Interface()) {
+					style := jsonName(sc.Type().Field(j))
+					styles = append(styles, member{style, sc.Field(j).Interface()})
+					keys = append(keys, name+"."+style)
+				}
+			}
+			diff = append(diff, member{name, styles})
+			continue
+		}
+		diff = append(diff, member{name, c})
+		keys = append(keys, name)
+	}
+	return diff, keys
+}
+
+// LoadOverrides reads a user overrides file: a JSON object from
+// TronThemePalette field to color. A color is hex, or a colors.css
+// variable of the variant such as "--gray950".
+//
+//	{"EditorBackground": "#0b0f14ff", "Comment": "--gray500"}
+func LoadOverrides(data []byte, colorsCSS []byte) (map[string]string, error) {
+	var raw map[string]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("reading overrides: %w", err)
+	}
+	colors, err := csscolors.LoadColors(colorsCSS)
+	if err != nil {
+		return nil, err
+	}
+
+	valid := map[string]bool{}
+	for _, field := range palette.ColorFields() {
+		valid[field] = true
+	}
+	fields := make(map[string]string, len(raw))
+	for field, value := range raw {
+		if !valid[field] {
+			return nil, fmt.Errorf("%s is not a TronThemePalette color field", field)
+		}
+		if name, ok := strings.CutPrefix(value, "--"); ok {
+			resolved, ok := colors.Get(name)
+			if !ok {
+				return nil, fmt.Errorf("%s: no colors.css variable %s", field, value)
+			}
+			value = resolved
+		}
+		if _, err := colormath.ParseHex(value); err != nil {
+			return nil, fmt.Errorf("%s: %w", field, err)
+		}
+		fields[field] = csscolors.CanonicalHex(value)
+	}
+	return fields, nil
+}
+
+// ThemeOverrides is what applying palette overrides to a variant changes
+type ThemeOverrides struct {
+	// Settings is a settings.json fragment holding an
+	// "experimental.theme_overrides" block with only the changed keys
+	Settings []byte
+	// Affected lists the style keys each overridden field changes on its
+	// own, in theme order
+	Affected map[string][]string
+}
+
+// NewThemeOverrides generates the variant's style with and without the
+// overridden palette fields and keeps the keys that differ
+func NewThemeOverrides(v palette.ThemeVariant, fields map[string]string) (ThemeOverrides, error) {
+	base := palette.GenerateThemeStyle(v.Name, v.Appearance, v.Palette).Style
+	apply := func(fields map[string]string) *palette.ThemeStyle {
+		p := v.Palette
+		for field, value := range fields {
+			p.Set(field, value)
+		}
+		return palette.GenerateThemeStyle(v.Name, v.Appearance, p).Style
+	}
+
+	o := ThemeOverrides{Affected: map[string][]string{}}
+	names := make([]string, 0, len(fields))
+	for field := range fields {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+	for _, field := range names {
+		_, keys := diffStyle(base, apply(map[string]string{field: fields[field]}))
+		o.Affected[field] = keys
+	}
+
+	diff, _ := diffStyle(base, apply(fields))
+	data, err := json.MarshalIndent(orderedObject{{"experimental.theme_overrides", diff}}, "", "  ")
+	if err != nil {
+		return ThemeOverrides{}, err
+	}
+	o.Settings = append(data, '\n')
+	return o, nil
+}
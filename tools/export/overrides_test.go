@@ -0,0 +1,89 @@
+package export_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/export"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/variants"
+)
+
+// styleMap returns a style as generic JSON
+func styleMap(t *testing.T, style *palette.ThemeStyle) map[string]any {
+	t.Helper()
+	data, err := json.Marshal(style)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestThemeOverrides(t *testing.T) {
+	v, _ := variants.Find("tron-legacy")
+	fields, err := export.LoadOverrides([]byte(`{"EditorBackground": "#0B0F14", "Comment": "--gray500", "Type": "#ff79c6ff"}`), variants.ColorsCSS(v))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields["EditorBackground"] != "#0b0f14ff" || fields["Comment"] != "#647c9bff" {
+		t.Errorf("LoadOverrides = %v", fields)
+	}
+
+	o, err := export.NewThemeOverrides(v, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var settings map[string]map[string]any
+	if err := json.Unmarshal(o.Settings, &settings); err != nil {
+		t.Fatal(err)
+	}
+	overrides := settings["experimental.theme_overrides"]
+	if overrides["editor.background"] != "#0b0f14ff" {
+		t.Errorf("editor.background = %v", overrides["editor.background"])
+	}
+	if _, ok := overrides["text"]; ok {
+		t.Error("unchanged key text was emitted")
+	}
+	if keys := o.Affected["EditorBackground"]; len(keys) == 0 || keys[0] != "editor.background" {
+		t.Errorf("EditorBackground affects %v", keys)
+	}
+
+	// Applying the overrides to the base style gives the overridden style
+	p := v.Palette
+	for field, value := range fields {
+		p.Set(field, value)
+	}
+	want := styleMap(t, palette.GenerateThemeStyle(v.Name, v.Appearance, p).Style)
+	got := styleMap(t, palette.GenerateThemeStyle(v.Name, v.Appearance, v.Palette).Style)
+	for key, value := range overrides {
+		if key == "syntax" {
+			for name, style := range value.(map[string]any) {
+				got["syntax"].(map[string]any)[name] = style
+			}
+			continue
+		}
+		got[key] = value
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Error("base style plus overrides differs from the overridden style")
+	}
+}
+
+func TestLoadOverridesErrors(t *testing.T) {
+	v, _ := variants.Find("tron-legacy")
+	for _, src := range []string{
+		`{"EditorBackgroun": "#000000"}`,
+		`{"EditorBackground": "--noSuchColor"}`,
+		`{"EditorBackground": "black"}`,
+		`{"EditorBackground": 1}`,
+	} {
+		if _, err := export.LoadOverrides([]byte(src), variants.ColorsCSS(v)); err == nil {
+			t.Errorf("%s: expected an error", src)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+// TemplateContext is the data a user template is executed with. It exposes
+// every layer of the pipeline so a template can pick whichever fits: raw
+// colors.css variables, semantic palette fields or resolved Zed style keys.
+type TemplateContext struct {
+	Name       string // display name, e.g. "Tron Legacy Light"
+	Slug       string // file friendly name, e.g. "tron-legacy-light"
+	Appearance string // "dark" or "light"
+	Dark       bool
+
+	// Desktop is the color translucent (frosted) backgrounds should be
+	// flattened over for tools without transparency
+	Desktop string
+
+	Variant palette.ThemeVariant
+	Colors  csscolors.ColorMap // colors.css variables, e.g. .Colors.gray900
+	Palette palette.TronThemePalette
+	Style   *palette.ThemeStyle
+}
+
+func parseColor(value string) (colormath.Color, error) {
+	return colormath.ParseHex(value)
+}
+
+// channels returns the 8-bit red, green and blue channels and the alpha
+func channels(value string) (r, g, b uint8, a float64, err error) {
+	c, err := parseColor(value)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	n := c.NRGBA()
+	return n.R, n.G, n.B, math.Round(c.A*1000) / 1000, nil
+}
+
+// TemplateFuncs returns the functions available to user templates. Color
+// arguments are hex strings as they appear in colors.css and the palette;
+// functions returning colors return lowercase hex.
+//
+//	hex        #rrggbbaa
+//	stripAlpha #rrggbb, dropping alpha without compositing
+//	bare       the value without its leading #
+//	rgb        rgb(r, g, b)
+//	rgba       rgba(r, g, b, a)
+//	hsl        hsl(h, s%, l%)
+//	alpha      alpha as a number from 0 to 1
+//	withAlpha  the color with a new alpha: withAlpha 0.5 .Palette.Foreground
+//	over       fg composited over bg: over .Palette.Selection .Palette.Background
+//	ansi256    nearest xterm 256-color index, alpha ignored
+//	kebab      Go field name to kebab-case: EditorBackground → editor-background
+//	snake      Go field name to snake_case
+//	upper      upper case
+//	lower      lower case
+//	list       its arguments as a list, for range
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"hex": func(value string) (string, error) {
+			c, err := parseColor(value)
+			return c.Hex(), err
+		},
+		"stripAlpha": func(value string) (string, error) {
+			c, err := parseColor(value)
+			return c.Hex()[:7], err
+		},
+		"bare": func(value string) string {
+			return strings.TrimPrefix(value, "#")
+		},
+		"rgb": func(value string) (string, error) {
+			r, g, b, _, err := channels(value)
+			return fmt.Sprintf("rgb(%d, %d, %d)", r, g, b), err
+		},
+		"rgba": func(value string) (string, error) {
+			r, g, b, a, err := channels(value)
+			return fmt.Sprintf("rgba(%d, %d, %d, %g)", r, g, b, a), err
+		},
+		"hsl": func(value string) (string, error) {
+			c, err := parseColor(value)
+			h := c.HSL()
+			return fmt.Sprintf("hsl(%.0f, %.0f%%, %.0f%%)", h.H, h.S*100, h.L*100), err
+		},
+		"alpha": func(value string) (float64, error) {
+			_, _, _, a, err := channels(value)
+			return a, err
+		},
+		"withAlpha": func(a float64, value string) (string, error) {
+			c, err := parseColor(value)
+			return c.WithAlpha(a).Hex(), err
+		},
+		"over": func(fg, bg string) (string, error) {
+			f, err := parseColor(fg)
+			if err != nil {
+				return "", err
+			}
+			b, err := parseColor(bg)
+			return f.Over(b).Hex(), err
+		},
+		"ansi256": func(value string) (int, error) {
+			c, err := parseColor(value)
+			return colormath.ANSI256(c), err
+		},
+		"kebab": kebab,
+		"snake": snake,
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"list":  func(values ...string) []string { return values },
+	}
+}
+
+// NewTemplateContext builds the context a variant's templates are executed
+// with from the colors.css its palette was loaded from
+func NewTemplateContext(v palette.ThemeVariant, p palette.TronThemePalette, style *palette.ThemeStyle, colorsCSS []byte) (TemplateContext, error) {
+	v.Palette = p
+	colors, err := csscolors.LoadColors(colorsCSS)
+	if err != nil {
+		return TemplateContext{}, err
+	}
+	return TemplateContext{
+		Name:       v.Name,
+		Slug:       v.Slug(),
+		Appearance: v.Appearance,
+		Dark:       v.Appearance == "dark",
+		Desktop:    palette.Desktop(v.Appearance).Hex(),
+		Variant:    v,
+		Colors:     colors,
+		Palette:    p,
+		Style:      style,
+	}, nil
+}
+
+// NewTemplateExporter parses a text/template and returns an exporter that
+// executes it with each variant's TemplateContext. Missing map keys such as
+// a misspelled .Colors entry are errors rather than "<no value>".
+func NewTemplateExporter(name, ext, src string) (Exporter, error) {
+	tmpl, err := template.New(name).Funcs(TemplateFuncs()).Option("missingkey=error").Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	return NewExporter(name, ext, func(v palette.ThemeVariant, p palette.TronThemePalette, style *palette.ThemeStyle, colorsCSS []byte) ([]byte, error) {
+		ctx, err := NewTemplateContext(v, p, style, colorsCSS)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, ctx); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}), nil
+}
+
+// LoadTemplates returns an exporter for every *.tmpl file in dir, sorted by
+// file name. The file name sets the exporter name and output extension:
+// kitty.conf.tmpl is exporter "kitty" writing <slug>.kitty.conf.
+func LoadTemplates(dir string) ([]Exporter, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var loaded []Exporter
+	for _, path := range paths {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		base := strings.TrimSuffix(filepath.Base(path), ".tmpl")
+		name, _, _ := strings.Cut(base, ".")
+		e, err := NewTemplateExporter(name, "."+base, string(src))
+		if err != nil {
+			return nil, err
+		}
+		loaded = append(loaded, e)
+	}
+	return loaded, nil
+}
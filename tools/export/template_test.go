@@ -0,0 +1,83 @@
+package export_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/export"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/export/exporttest"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/variants"
+)
+
+func renderTemplate(t *testing.T, src string) (string, error) {
+	t.Helper()
+	e, err := export.NewTemplateExporter("test", ".txt", src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, _ := variants.Find("Tron Legacy")
+	out, err := export.Run(e, v, variants.ColorsCSS(v))
+	return string(out), err
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	cases := map[string]string{
+		`{{hex "#6EE2FF"}}`:                            "#6ee2ffff",
+		`{{stripAlpha "#6ee2ff1a"}}`:                   "#6ee2ff",
+		`{{bare (stripAlpha "#6ee2ff1a")}}`:            "6ee2ff",
+		`{{rgb "#6ee2ff1a"}}`:                          "rgb(110, 226, 255)",
+		`{{rgba "#6ee2ff80"}}`:                         "rgba(110, 226, 255, 0.502)",
+		`{{hsl "#ff0000"}}`:                            "hsl(0, 100%, 50%)",
+		`{{alpha "#00000000"}}`:                        "0",
+		`{{withAlpha 0.5 "#ffffff"}}`:                  "#ffffff80",
+		`{{over "#ffffff80" "#000000"}}`:               "#808080ff",
+		`{{ansi256 "#ff0000"}}`:                        "196",
+		`{{kebab "EditorBackground"}}`:                 "editor-background",
+		`{{snake "UIAccent" | upper}}`:                 "UI_ACCENT",
+		`{{range list "a" "b"}}{{.}}{{end}}`:           "ab",
+		`{{.Slug}} {{.Appearance}} {{.Dark}}`:          "tron-legacy dark true",
+		`{{.Colors.gray900 | eq .Palette.Background}}`: "true",
+	}
+	for src, want := range cases {
+		got, err := renderTemplate(t, src)
+		if err != nil || got != want {
+			t.Errorf("%s = %q, %v; want %q", src, got, err, want)
+		}
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	for _, src := range []string{
+		`{{.Colors.noSuchColor}}`,
+		`{{hex "transparent"}}`,
+		`{{.NoSuchField}}`,
+	} {
+		if _, err := renderTemplate(t, src); err == nil {
+			t.Errorf("%s: expected an error", src)
+		}
+	}
+}
+
+func TestExampleTemplatesGolden(t *testing.T) {
+	templates, err := export.LoadTemplates("../../templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(templates) == 0 {
+		t.Fatal("no example templates found")
+	}
+	vs := findGoldenVariants(t)
+	for _, e := range templates {
+		if !strings.HasPrefix(e.Extension(), "."+e.Name()+".") {
+			t.Errorf("%s: extension %s does not start with the template name", e.Name(), e.Extension())
+		}
+		t.Run(e.Name(), func(t *testing.T) {
+			exporttest.Golden(t, e, "testdata/templates", vs...)
+			for _, v := range variants.All() {
+				if _, err := export.Run(e, v, variants.ColorsCSS(v)); err != nil {
+					t.Errorf("%s: %v", v.Name, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+func init() {
+	Register(NewExporter("windows-terminal", ".windows-terminal.json", windowsTerminal))
+	Register(NewExporter("iterm2", ".itermcolors", iTerm2))
+	Register(NewExporter("xresources", ".Xresources", xresources))
+}
+
+// ansiNames are the 16 ANSI colors in order, named the way Windows
+// Terminal names them
+var ansiNames = [16]string{
+	"black", "red", "green", "yellow", "blue", "purple", "cyan", "white",
+	"brightBlack", "brightRed", "brightGreen", "brightYellow", "brightBlue", "brightPurple", "brightCyan", "brightWhite",
+}
+
+// terminalScheme is a variant's terminal colors, flattened to opaque colors
+// over the terminal background since terminal color schemes have no alpha
+type terminalScheme struct {
+	name       string
+	foreground colormath.Color
+	background colormath.Color
+	selection  colormath.Color
+	cursor     colormath.Color
+	ansi       [16]colormath.Color
+}
+
+func newTerminalScheme(v palette.ThemeVariant, p palette.TronThemePalette, style *palette.ThemeStyle) (terminalScheme, error) {
+	bg, err := colormath.ParseHex(p.Background)
+	if err != nil {
+		return terminalScheme{}, fmt.Errorf("Background: %w", err)
+	}
+	s := terminalScheme{name: v.Name, background: bg.Over(palette.Desktop(v.Appearance))}
+
+	// The cursor is the first player's, which Zed draws for the local user
+	players := style.Players
+	if len(players) == 0 {
+		return terminalScheme{}, fmt.Errorf("%s has no players", v.Name)
+	}
+
+	for _, f := range []struct {
+		field string
+		hex   string
+		dst   *colormath.Color
+	}{
+		{"Foreground", p.Foreground, &s.foreground},
+		{"Selection", p.Selection, &s.selection},
+		{"Player1 cursor", players[0].Cursor, &s.cursor},
+		{"TerminalBlack", p.TerminalBlack, &s.ansi[0]},
+		{"TerminalRed", p.TerminalRed, &s.ansi[1]},
+		{"TerminalGreen", p.TerminalGreen, &s.ansi[2]},
+		{"TerminalYellow", p.TerminalYellow, &s.ansi[3]},
+		{"TerminalBlue", p.TerminalBlue, &s.ansi[4]},
+		{"TerminalPurple", p.TerminalPurple, &s.ansi[5]},
+		{"TerminalCyan", p.TerminalCyan, &s.ansi[6]},
+		{"TerminalWhite", p.TerminalWhite, &s.ansi[7]},
+		{"TerminalBrightBlack", p.TerminalBrightBlack, &s.ansi[8]},
+		{"TerminalBrightRed", p.TerminalBrightRed, &s.ansi[9]},
+		{"TerminalBrightGreen", p.TerminalBrightGreen, &s.ansi[10]},
+		{"TerminalBrightYellow", p.TerminalBrightYellow, &s.ansi[11]},
+		{"TerminalBrightBlue", p.TerminalBrightBlue, &s.ansi[12]},
+		{"TerminalBrightPurple", p.TerminalBrightPurple, &s.ansi[13]},
+		{"TerminalBrightCyan", p.TerminalBrightCyan, &s.ansi[14]},
+		{"TerminalBrightWhite", p.TerminalBrightWhite, &s.ansi[15]},
+	} {
+		c, err := colormath.ParseHex(f.hex)
+		if err != nil {
+			return terminalScheme{}, fmt.Errorf("%s: %w", f.field, err)
+		}
+		*f.dst = c.Over(s.background)
+	}
+	return s, nil
+}
+
+func rgb(c colormath.Color) string {
+	return c.Hex()[:7]
+}
+
+// windowsTerminal returns a Windows Terminal color scheme, the object that
+// goes in the "schemes" array of settings.json
+func windowsTerminal(v palette.ThemeVariant, p palette.TronThemePalette, style *palette.ThemeStyle, _ []byte) ([]byte, error) {
+	s, err := newTerminalScheme(v, p, style)
+	if err != nil {
+		return nil, err
+	}
+	// Build the object by hand so the keys keep Windows Terminal's order
+	var b strings.Builder
+	b.WriteString("{\n")
+	field := func(key, value string, last bool) {
+		k, _ := json.Marshal(key)
+		val, _ := json.Marshal(value)
+		b.WriteString("  " + string(k) + ": " + string(val))
+		if !last {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	field("name", s.name, false)
+	field("background", rgb(s.background), false)
+	field("foreground", rgb(s.foreground), false)
+	field("cursorColor", rgb(s.cursor), false)
+	field("selectionBackground", rgb(s.selection), false)
+	for i, name := range ansiNames {
+		field(name, rgb(s.ansi[i]), i == len(ansiNames)-1)
+	}
+	b.WriteString("}\n")
+	return []byte(b.String()), nil
+}
+
+// iTerm2 returns an iTerm2 .itermcolors property list
+func iTerm2(v palette.ThemeVariant, p palette.TronThemePalette, style *palette.ThemeStyle, _ []byte) ([]byte, error) {
+	s, err := newTerminalScheme(v, p, style)
+	if err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+`)
+	fmt.Fprintf(&b, "<!-- %s. Generated by tools/generate-theme.go; do not edit. -->\n", html.EscapeString(s.name))
+	b.WriteString("<dict>\n")
+	entry := func(key string, c colormath.Color) {
+		fmt.Fprintf(&b, "\t<key>%s</key>\n\t<dict>\n", key)
+		fmt.Fprintf(&b, "\t\t<key>Alpha Component</key>\n\t\t<real>1</real>\n")
+		fmt.Fprintf(&b, "\t\t<key>Blue Component</key>\n\t\t<real>%.6f</real>\n", c.B)
+		fmt.Fprintf(&b, "\t\t<key>Color Space</key>\n\t\t<string>sRGB</string>\n")
+		fmt.Fprintf(&b, "\t\t<key>Green Component</key>\n\t\t<real>%.6f</real>\n", c.G)
+		fmt.Fprintf(&b, "\t\t<key>Red Component</key>\n\t\t<real>%.6f</real>\n", c.R)
+		b.WriteString("\t</dict>\n")
+	}
+	colors := map[string]colormath.Color{
+		"Background Color":    s.background,
+		"Bold Color":          s.foreground,
+		"Cursor Color":        s.cursor,
+		"Cursor Text Color":   s.background,
+		"Foreground Color":    s.foreground,
+		"Selected Text Color": s.foreground,
+		"Selection Color":     s.selection,
+	}
+	for i, c := range s.ansi {
+		colors[fmt.Sprintf("Ansi %d Color", i)] = c
+	}
+	// Sorted like iTerm2 saves them, so Ansi 10 comes before Ansi 2
+	keys := make([]string, 0, len(colors))
+	for key := range colors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		entry(key, colors[key])
+	}
+	b.WriteString("</dict>\n</plist>\n")
+	return []byte(b.String()), nil
+}
+
+// xresources returns X resources for xterm, urxvt and other X terminals
+func xresources(v palette.ThemeVariant, p palette.TronThemePalette, style *palette.ThemeStyle, _ []byte) ([]byte, error) {
+	s, err := newTerminalScheme(v, p, style)
+	if err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "! %s. Generated by tools/generate-theme.go; do not edit.\n\n", s.name)
+	fmt.Fprintf(&b, "*.foreground: %s\n", rgb(s.foreground))
+	fmt.Fprintf(&b, "*.background: %s\n", rgb(s.background))
+	fmt.Fprintf(&b, "*.cursorColor: %s\n", rgb(s.cursor))
+	fmt.Fprintf(&b, "*.highlightColor: %s\n", rgb(s.selection))
+	b.WriteString("\n")
+	for i, c := range s.ansi {
+		fmt.Fprintf(&b, "*.color%d: %s\n", i, rgb(c))
+	}
+	return []byte(b.String()), nil
+}
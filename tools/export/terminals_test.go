@@ -0,0 +1,31 @@
+package export
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/variants"
+)
+
+func TestWindowsTerminalScheme(t *testing.T) {
+	for _, v := range variants.All() {
+		data := mustRun(t, "windows-terminal", v)
+		var scheme map[string]string
+		if err := json.Unmarshal(data, &scheme); err != nil {
+			t.Fatalf("%s: invalid JSON: %v", v.Name, err)
+		}
+		if scheme["name"] != v.Name {
+			t.Errorf("%s: name = %q", v.Name, scheme["name"])
+		}
+		// Windows Terminal rejects colors with alpha
+		for key, value := range scheme {
+			if key != "name" && (len(value) != 7 || !strings.HasPrefix(value, "#")) {
+				t.Errorf("%s: %s = %q, want #rrggbb", v.Name, key, value)
+			}
+		}
+		if len(scheme) != 5+len(ansiNames) {
+			t.Errorf("%s: %d keys, want %d", v.Name, len(scheme), 5+len(ansiNames))
+		}
+	}
+}
@@ -0,0 +1,46 @@
+"""Tron Legacy Light Frosted style for Pygments. Generated by tools/generate-theme.go; do not edit."""
+
+from pygments.style import Style
+from pygments.token import Comment, Generic, Keyword, Name, Number, Operator, Punctuation, String, Text
+
+
+class TronLegacyLightFrostedStyle(Style):
+    name = "tron-legacy-light-frosted"
+    background_color = "#f6f7fa"
+    highlight_color = "#f1f4f8"
+    line_number_color = "#8a9db5"
+
+    styles = {
+        Text: "#3a4a5a",
+        Comment: "#6b7e96",
+        String.Doc: "#6b7e96",
+        Comment.Preproc: "#0099cc",
+        Keyword: "italic #1a5f8a",
+        Keyword.Constant: "italic #cc7700",
+        Keyword.Type: "italic bold #1a5f8a",
+        Operator: "#1a5f8a",
+        Punctuation: "#2d3e4f",
+        Name.Constant: "italic #cc7700",
+        Name.Function: "#cc7700",
+        Name.Builtin: "italic #1a5f8a",
+        Name.Class: "italic bold #e68a00",
+        Name.Tag: "#1a5f8a",
+        Name.Attribute: "#e68a00",
+        Name.Property: "#5a8b2c",
+        Name.Variable: "#267fb5",
+        Name.Builtin.Pseudo: "italic #6a56cc",
+        Name.Namespace: "#3988c0",
+        Name.Label: "#d1459a",
+        Name.Decorator: "#d1459a",
+        String: "#d91e18",
+        String.Escape: "#e74c3c",
+        String.Regex: "#0099cc",
+        String.Symbol: "#cc7700",
+        String.Interpol: "#dbb200",
+        Number: "#7aad3a",
+        Generic.Heading: "bold #267fb5",
+        Generic.Emph: "italic #0099cc",
+        Generic.Strong: "bold #cc7700",
+        Generic.Inserted: "#7aad3a",
+        Generic.Deleted: "#cc0033",
+    }
@@ -0,0 +1,46 @@
+"""Tron Legacy style for Pygments. Generated by tools/generate-theme.go; do not edit."""
+
+from pygments.style import Style
+from pygments.token import Comment, Generic, Keyword, Name, Number, Operator, Punctuation, String, Text
+
+
+class TronLegacyStyle(Style):
+    name = "tron-legacy"
+    background_color = "#14191f"
+    highlight_color = "#1a1f26"
+    line_number_color = "#647c9b"
+
+    styles = {
+        Text: "#aec2e0",
+        Comment: "#586676",
+        String.Doc: "#586676",
+        Comment.Preproc: "#6ee2ff",
+        Keyword: "italic #267fb5",
+        Keyword.Constant: "italic #ffb20d",
+        Keyword.Type: "italic bold #267fb5",
+        Operator: "#267fb5",
+        Punctuation: "#aec2e0",
+        Name.Constant: "italic #ffb20d",
+        Name.Function: "#ffb20d",
+        Name.Builtin: "italic #267fb5",
+        Name.Class: "italic bold #f79d1e",
+        Name.Tag: "#267fb5",
+        Name.Attribute: "#f79d1e",
+        Name.Property: "#95cc5e",
+        Name.Variable: "#c8d9e8",
+        Name.Builtin.Pseudo: "italic #967efb",
+        Name.Namespace: "#4a95b3",
+        Name.Label: "#ff79c6",
+        Name.Decorator: "#ff79c6",
+        String: "#ff410d",
+        String.Escape: "#ff5f52",
+        String.Regex: "#6ee2ff",
+        String.Symbol: "#ffb20d",
+        String.Interpol: "#ffd12c",
+        Number: "#c7f026",
+        Generic.Heading: "bold #c8d9e8",
+        Generic.Emph: "italic #6ee2ff",
+        Generic.Strong: "bold #ffb20d",
+        Generic.Inserted: "#c7f026",
+        Generic.Deleted: "#f92672",
+    }
@@ -0,0 +1,198 @@
+package export
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+// Property is a semantic CSS custom property
+type Property struct {
+	Name  string // without the leading --
+	Value string
+}
+
+// kebab turns a Go field name into a CSS name: EditorBackground →
+// editor-background, UIAccent → ui-accent
+func kebab(s string) string {
+	return strings.ReplaceAll(snake(s), "_", "-")
+}
+
+// snake turns a Go field name into lower_snake_case, keeping acronyms whole
+func snake(s string) string {
+	r := []rune(s)
+	var b strings.Builder
+	for i, c := range r {
+		if unicode.IsUpper(c) && i > 0 && (unicode.IsLower(r[i-1]) || unicode.IsDigit(r[i-1]) || i+1 < len(r) && unicode.IsLower(r[i+1])) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(c))
+	}
+	return b.String()
+}
+
+// webHex drops the alpha byte of opaque colors, which browsers and
+// preprocessors read more easily
+func webHex(value string) string {
+	value = strings.ToLower(value)
+	if len(value) == 9 && strings.HasSuffix(value, "ff") {
+		return value[:7]
+	}
+	return value
+}
+
+// Properties returns the semantic custom properties of a variant, prefixed
+// --tron-, in palette field order followed by the accents
+func Properties(v palette.ThemeVariant) []Property {
+	var props []Property
+	for _, field := range palette.ColorFields() {
+		if value, _ := v.Palette.Get(field); strings.HasPrefix(value, "#") {
+			props = append(props, Property{Name: "tron-" + kebab(field), Value: webHex(value)})
+		}
+	}
+	for i, accent := range v.Palette.Accents {
+		props = append(props, Property{Name: fmt.Sprintf("tron-accent-%d", i), Value: webHex(accent)})
+	}
+	return props
+}
+
+func writeDeclarations(b *strings.Builder, indent string, v palette.ThemeVariant) {
+	fmt.Fprintf(b, "%scolor-scheme: %s;\n", indent, v.Appearance)
+	for _, p := range Properties(v) {
+		fmt.Fprintf(b, "%s--%s: %s;\n", indent, p.Name, p.Value)
+	}
+}
+
+// CSS returns a stylesheet with every variant's semantic properties under
+// [data-theme="<slug>"]. The first dark and first light variant are also
+// the defaults for pages without data-theme, following prefers-color-scheme.
+func CSS(vs []palette.ThemeVariant) []byte {
+	var b strings.Builder
+	b.WriteString("/* Tron Legacy semantic colors. Generated by tools/generate-theme.go web; do not edit. */\n")
+
+	var dark, light *palette.ThemeVariant
+	for i := range vs {
+		if vs[i].Appearance == "light" && light == nil {
+			light = &vs[i]
+		} else if vs[i].Appearance != "light" && dark == nil {
+			dark = &vs[i]
+		}
+	}
+
+	for _, v := range vs {
+		selector := fmt.Sprintf("[data-theme=%q]", v.Slug())
+		if dark != nil && v.Name == dark.Name {
+			selector = ":root,\n" + selector
+		}
+		fmt.Fprintf(&b, "\n%s {\n", selector)
+		writeDeclarations(&b, "  ", v)
+		b.WriteString("}\n")
+	}
+
+	if light != nil {
+		b.WriteString("\n@media (prefers-color-scheme: light) {\n  :root:not([data-theme]) {\n")
+		writeDeclarations(&b, "    ", *light)
+		b.WriteString("  }\n}\n")
+	}
+	return []byte(b.String())
+}
+
+// SCSS returns a map per variant, $tron-legacy-light: ("background": ...),
+// and a $tron-themes map of all of them keyed by slug
+func SCSS(vs []palette.ThemeVariant) []byte {
+	var b strings.Builder
+	b.WriteString("// Tron Legacy semantic colors. Generated by tools/generate-theme.go web; do not edit.\n")
+	for _, v := range vs {
+		fmt.Fprintf(&b, "\n$%s: (\n", v.Slug())
+		for _, p := range Properties(v) {
+			fmt.Fprintf(&b, "  %q: %s,\n", strings.TrimPrefix(p.Name, "tron-"), p.Value)
+		}
+		b.WriteString(");\n")
+	}
+	b.WriteString("\n$tron-themes: (\n")
+	for _, v := range vs {
+		fmt.Fprintf(&b, "  %q: $%s,\n", v.Slug(), v.Slug())
+	}
+	b.WriteString(");\n")
+	return []byte(b.String())
+}
+
+var scaleName = regexp.MustCompile(`^([a-z]+)(\d+)$`)
+
+// scales groups the opaque colors.css variables named like blue200 into
+// families keyed by step, e.g. blue → {200: ..., 500: ...}
+func scales(colorsCSS []byte) (map[string]map[int]string, error) {
+	colors, err := csscolors.LoadColorList(colorsCSS)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]map[int]string{}
+	for _, c := range colors {
+		m := scaleName.FindStringSubmatch(c.Name)
+		if m == nil || !strings.HasPrefix(c.Value, "#") || !strings.HasSuffix(strings.ToLower(c.Value), "ff") {
+			continue
+		}
+		step, _ := strconv.Atoi(m[2])
+		if out[m[1]] == nil {
+			out[m[1]] = map[int]string{}
+		}
+		out[m[1]][step] = webHex(c.Value)
+	}
+	return out, nil
+}
+
+// Tailwind returns a Tailwind CSS preset. Semantic colors point at the
+// --tron- custom properties from CSS, so they follow data-theme; the raw
+// dark and light scales are included as tron.dark.* and tron.light.*.
+func Tailwind(vs []palette.ThemeVariant, darkCSS, lightCSS []byte) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString("// Tron Legacy Tailwind CSS preset. Generated by tools/generate-theme.go web; do not edit.\n")
+	b.WriteString("// Load tron-legacy.css for the custom properties the semantic colors use.\n")
+	b.WriteString("module.exports = {\n  theme: {\n    extend: {\n      colors: {\n        tron: {\n")
+	if len(vs) > 0 {
+		for _, p := range Properties(vs[0]) {
+			fmt.Fprintf(&b, "          %q: \"var(--%s)\",\n", strings.TrimPrefix(p.Name, "tron-"), p.Name)
+		}
+	}
+
+	for _, base := range []struct {
+		name string
+		css  []byte
+	}{{"dark", darkCSS}, {"light", lightCSS}} {
+		families, err := scales(base.css)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(families))
+		for name := range families {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fmt.Fprintf(&b, "          %s: {\n", base.name)
+		for _, name := range names {
+			steps := make([]int, 0, len(families[name]))
+			for step := range families[name] {
+				steps = append(steps, step)
+			}
+			sort.Ints(steps)
+			fmt.Fprintf(&b, "            %s: {", name)
+			for i, step := range steps {
+				if i > 0 {
+					b.WriteString(",")
+				}
+				fmt.Fprintf(&b, " %d: %q", step, families[name][step])
+			}
+			b.WriteString(" },\n")
+		}
+		b.WriteString("          },\n")
+	}
+	b.WriteString("        },\n      },\n    },\n  },\n};\n")
+	return []byte(b.String()), nil
+}
@@ -0,0 +1,66 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/dark"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/light"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/variants"
+)
+
+func TestKebab(t *testing.T) {
+	for in, want := range map[string]string{
+		"EditorBackground":    "editor-background",
+		"UIAccent":            "ui-accent",
+		"VCSModified":         "vcs-modified",
+		"Player1":             "player1",
+		"TerminalBrightBlack": "terminal-bright-black",
+	} {
+		if got := kebab(in); got != want {
+			t.Errorf("kebab(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCSS(t *testing.T) {
+	all := variants.All()
+	css := string(CSS(all))
+	for _, v := range all {
+		if !strings.Contains(css, `[data-theme="`+v.Slug()+`"] {`) {
+			t.Errorf("missing selector for %s", v.Name)
+		}
+	}
+	if !strings.Contains(css, ":root,\n[data-theme=\"tron-legacy\"]") {
+		t.Error("Tron Legacy is not the default theme")
+	}
+	if !strings.Contains(css, "@media (prefers-color-scheme: light) {\n  :root:not([data-theme]) {\n    color-scheme: light;\n    --tron-background: #f5f7fa;") {
+		t.Error("light media query does not default to Tron Legacy Light")
+	}
+	for _, p := range Properties(all[0]) {
+		if strings.Count(css, "--"+p.Name+":") != len(all)+1 {
+			t.Errorf("--%s is not declared for every variant", p.Name)
+		}
+	}
+}
+
+func TestSCSSAndTailwind(t *testing.T) {
+	vs := []palette.ThemeVariant{variants.All()[0], variants.All()[2]}
+	scss := string(SCSS(vs))
+	for _, want := range []string{`$tron-legacy: (`, `$tron-legacy-light: (`, `"editor-background": #14191f,`, `"tron-legacy-light": $tron-legacy-light,`} {
+		if !strings.Contains(scss, want) {
+			t.Errorf("SCSS is missing %s", want)
+		}
+	}
+
+	tw, err := Tailwind(vs, dark.ColorsCSS(), light.ColorsCSS())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"editor-background": "var(--tron-editor-background)",`, `gray: { 50: "#dae3f1"`, `blue: { 200: "#0099cc"`} {
+		if !strings.Contains(string(tw), want) {
+			t.Errorf("Tailwind preset is missing %s", want)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package export
+
+import "github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+
+func init() {
+	Register(NewExporter("zed", ".json", zed))
+}
+
+// zed returns a Zed theme family holding only this variant, for installing
+// one variant without the others
+func zed(v palette.ThemeVariant, p palette.TronThemePalette, _ *palette.ThemeStyle, _ []byte) ([]byte, error) {
+	v.Palette = p
+	return marshal(palette.GenerateTheme(v.Name, "Bret Comnes", v))
+}
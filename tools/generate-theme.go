@@ -1,67 +1,789 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/cvd"
 	"github.com/bcomnes/zed-theme-tron-legacy/tools/dark"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/derive"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/export"
 	"github.com/bcomnes/zed-theme-tron-legacy/tools/light"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/lint"
 	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/preview"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/screenshot"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/utils/colorvalidation"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/variants"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/watch"
 )
 
+// commands maps subcommand names to their entry points.
+// Running without a subcommand generates the theme JSON.
+var commands = map[string]func(args []string) error{
+	"generate":     generate,
+	"preview":      previewCmd,
+	"derive-light": deriveLight,
+	"export":       exportCmd,
+	"frosted":      frosted,
+	"fmt":          fmtCmd,
+	"highlighters": highlighters,
+	"lint":         lintCmd,
+	"overrides":    overridesCmd,
+	"ramp":         ramp,
+	"terminals":    terminals,
+	"tokens":       tokensCmd,
+	"watch":        watchCmd,
+	"web":          web,
+	"screenshots":  screenshots,
+}
+
 func main() {
-	// Get palettes from the respective packages
-	darkPalette := dark.GetPalette()
-	lightPalette := light.GetPalette()
-	darkFrostedPalette := dark.GetFrostedPalette()
-	lightFrostedPalette := light.GetFrostedPalette()
-
-	// Create theme variants
-	variants := []palette.ThemeVariant{
-		{
-			Name:       "Tron Legacy",
-			Appearance: "dark",
-			Palette:    darkPalette,
-		},
-		{
-			Name:       "Tron Legacy Frosted",
-			Appearance: "dark",
-			Palette:    darkFrostedPalette,
-		},
-		{
-			Name:       "Tron Legacy Light",
-			Appearance: "light",
-			Palette:    lightPalette,
-		},
-		{
-			Name:       "Tron Legacy Light Frosted",
-			Appearance: "light",
-			Palette:    lightFrostedPalette,
-		},
+	name, args := "generate", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Printf("Unknown command %q\n", name)
+		fmt.Println("Available commands: generate, preview, screenshots, derive-light, export, fmt, frosted, highlighters, lint, overrides, ramp, terminals, tokens, watch, web")
+		os.Exit(2)
+	}
+
+	if err := cmd(args); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
 	}
+}
+
+// generate writes the Zed theme JSON
+func generate(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	outputPath := fs.String("out", "../themes/tron-legacy.json", "output theme file")
+	annotatePath := fs.String("annotate", "", "also write a JSONC copy commenting each color's palette field and colors.css variable to this file")
+	fs.Parse(args)
 
 	// Generate the complete theme using the palette package
+	all := variants.All()
 	theme := palette.GenerateTheme(
 		"Tron Legacy",
 		"Bret Comnes",
-		variants...,
+		all...,
 	)
 
 	// Marshal to JSON with indentation
 	jsonData, err := json.MarshalIndent(theme, "", "  ")
 	if err != nil {
-		fmt.Printf("Error marshaling theme: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("marshaling theme: %w", err)
 	}
 
 	// Write to output file
-	outputPath := "../themes/tron-legacy.json"
-	err = os.WriteFile(outputPath, jsonData, 0644)
-	if err != nil {
-		fmt.Printf("Error writing file: %v\n", err)
-		os.Exit(1)
+	if err := os.WriteFile(*outputPath, jsonData, 0644); err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	if *annotatePath != "" {
+		srcs := make([]export.AnnotateSource, len(all))
+		for i, v := range all {
+			srcs[i] = export.AnnotateSource{Variant: v, ColorsCSS: variants.ColorsCSS(v), PaletteGo: variants.PaletteSource(v)}
+		}
+		annotated, err := export.Annotate(jsonData, srcs)
+		if err != nil {
+			return fmt.Errorf("annotating theme: %w", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(*annotatePath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(*annotatePath, annotated, 0644); err != nil {
+			return fmt.Errorf("writing %s: %w", *annotatePath, err)
+		}
+		fmt.Printf("Annotated theme written to %s\n", *annotatePath)
 	}
 
 	fmt.Println("Theme generated successfully!")
+	return nil
+}
+
+// screenshots renders PNG previews of every variant
+func screenshots(args []string) error {
+	fs := flag.NewFlagSet("screenshots", flag.ExitOnError)
+	outDir := fs.String("out", "../screenshots/generated", "output directory")
+	examplesDir := fs.String("examples", "../examples", "directory of example source files")
+	file := fs.String("file", "example.js", "example file shown in the editor")
+	simulate := fs.Bool("cvd", false, "also render color vision deficiency simulations")
+	fs.Parse(args)
+
+	source, err := os.ReadFile(filepath.Join(*examplesDir, *file))
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(*examplesDir)
+	if err != nil {
+		return err
+	}
+
+	opts := screenshot.DefaultOptions()
+	opts.FileName = *file
+	opts.Source = source
+	if *simulate {
+		opts.Deficiencies = cvd.Deficiencies
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			opts.ProjectFiles = append(opts.ProjectFiles, e.Name())
+		}
+	}
+
+	if err := screenshot.WriteAll(variants.All(), *outDir, opts); err != nil {
+		return err
+	}
+
+	fmt.Printf("Screenshots written to %s\n", *outDir)
+	return nil
+}
+
+// previewCmd prints a truecolor ANSI preview of one or all variants
+func previewCmd(args []string) error {
+	fs := flag.NewFlagSet("preview", flag.ExitOnError)
+	variantName := fs.String("variant", "all", "variant name or slug, or \"all\"")
+	examplesDir := fs.String("examples", "../examples", "directory of example source files")
+	file := fs.String("file", "example.js", "example file to highlight (empty to skip)")
+	lines := fs.Int("lines", 30, "number of sample lines to print")
+	width := fs.Int("width", 100, "terminal width in columns")
+	fs.Parse(args)
+
+	selected := variants.All()
+	if *variantName != "all" {
+		v, ok := variants.Find(*variantName)
+		if !ok {
+			return fmt.Errorf("unknown variant %q", *variantName)
+		}
+		selected = []palette.ThemeVariant{v}
+	}
+
+	opts := preview.Options{Width: *width, FileName: *file, SampleLines: *lines}
+	if *file != "" {
+		source, err := os.ReadFile(filepath.Join(*examplesDir, *file))
+		if err != nil {
+			return err
+		}
+		opts.Source = source
+	}
+
+	for _, v := range selected {
+		if err := preview.Write(os.Stdout, v, variants.ColorsCSS(v), opts); err != nil {
+			return fmt.Errorf("%s: %w", v.Name, err)
+		}
+	}
+	return nil
+}
+
+// deriveLight proposes a light palette derived from the dark one, named
+// after the light variables, and diffs it against light/colors.css to show
+// how far the hand-tuned light palette has drifted
+func deriveLight(args []string) error {
+	defaults := derive.DefaultLightOptions()
+	fs := flag.NewFlagSet("derive-light", flag.ExitOnError)
+	outDir := fs.String("out", "../dist/derived-light", "output directory for colors.css and colors.css.diff")
+	threshold := fs.Float64("threshold", 10, "show changed variables whose ΔE from the derived color is at least this")
+	opts := derive.LightOptions{}
+	fs.Float64Var(&opts.BackgroundL, "background-l", defaults.BackgroundL, "OKLCH lightness of the derived background")
+	fs.Float64Var(&opts.ForegroundL, "foreground-l", defaults.ForegroundL, "OKLCH lightness of the derived strongest text")
+	fs.Float64Var(&opts.TextContrast, "contrast", defaults.TextContrast, "minimum WCAG contrast for text")
+	fs.Parse(args)
+
+	proposal, err := derive.ProposeLight(dark.ColorsCSS(), dark.GetPalette(), opts)
+	if err != nil {
+		return err
+	}
+	if err := proposal.UseNames(light.ColorsCSS(), light.GetPalette()); err != nil {
+		return err
+	}
+	changes, err := proposal.Diff(light.ColorsCSS(), light.GetPalette())
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(*outDir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(*outDir, "colors.css"), proposal.ColorsCSS(), 0644); err != nil {
+		return fmt.Errorf("writing colors.css: %w", err)
+	}
+	var report strings.Builder
+	derive.WriteDiff(&report, changes, *threshold)
+	if err := os.WriteFile(filepath.Join(*outDir, "colors.css.diff"), []byte(report.String()), 0644); err != nil {
+		return fmt.Errorf("writing colors.css.diff: %w", err)
+	}
+
+	fmt.Print(report.String())
+	fmt.Printf("Derived light colors written to %s\n", *outDir)
+	return nil
+}
+
+// frosted prints the frosted override layer for custom opacities
+func frosted(args []string) error {
+	fs := flag.NewFlagSet("frosted", flag.ExitOnError)
+	base := fs.String("base", "dark", "base palette: dark or light")
+	opts := derive.FrostedOptions{}
+	fs.Float64Var(&opts.BackgroundOpacity, "background", 0.8, "window background opacity")
+	fs.Float64Var(&opts.EditorOpacity, "editor", 0.93, "editor background opacity")
+	fs.Float64Var(&opts.BorderOpacity, "border", 0.67, "border opacity")
+	fs.Float64Var(&opts.MinContrast, "contrast", 4.5, "text contrast to keep over black and white desktops")
+	fs.Parse(args)
+
+	var p palette.TronThemePalette
+	switch *base {
+	case "dark":
+		p = dark.GetPalette()
+	case "light":
+		p = light.GetPalette()
+	default:
+		return fmt.Errorf("unknown base palette %q", *base)
+	}
+
+	result := derive.Frosted(p, opts)
+	for _, line := range derive.Overrides(p, result) {
+		fmt.Println(line)
+	}
+	for _, issue := range derive.CheckFrosted(p, result, opts.MinContrast) {
+		fmt.Printf("warning: %s on %s over %s desktop has contrast %.2f\n", issue.Field, issue.Surface, issue.Desktop.Hex(), issue.Contrast)
+	}
+	return nil
+}
+
+// fmtCmd canonicalizes colors.css: lowercase #rrggbbaa values, one space
+// around each comment and, with -usage, comments listing the palette fields
+// that use each color. colors_gen.go is regenerated to match.
+func fmtCmd(args []string) error {
+	fs := flag.NewFlagSet("fmt", flag.ExitOnError)
+	base := fs.String("base", "all", "colors.css to format: dark, light or all")
+	usage := fs.Bool("usage", false, "rewrite the field list at the start of each comment from palette.go")
+	check := fs.Bool("check", false, "list files that need formatting instead of writing them")
+	merges := fs.Float64("merges", 0, "report opaque colors closer than this CIEDE2000 ΔE and suggest merges instead of formatting")
+	fs.Parse(args)
+
+	var dirs []string
+	switch *base {
+	case "all":
+		dirs = []string{"dark", "light"}
+	case "dark", "light":
+		dirs = []string{*base}
+	default:
+		return fmt.Errorf("unknown base palette %q", *base)
+	}
+
+	var unformatted []string
+	for _, dir := range dirs {
+		path := filepath.Join(dir, "colors.css")
+		original, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if *merges > 0 {
+			colors, err := csscolors.LoadColorList(original)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			found := colorvalidation.FindNearDuplicates(colors, *merges)
+			fmt.Printf("%s: %d near-duplicate pairs\n", path, len(found))
+			for _, d := range found {
+				fmt.Printf("  %s\n", d.Suggestion())
+			}
+			continue
+		}
+		f := csscolors.ParseFile(original)
+		f.Format()
+
+		if *usage {
+			source, err := os.ReadFile(filepath.Join(dir, "palette.go"))
+			if err != nil {
+				return err
+			}
+			colors, err := csscolors.LoadColorList(original)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			usages, err := csscolors.Usages(source, colors)
+			if err != nil {
+				return fmt.Errorf("%s/palette.go: %w", dir, err)
+			}
+			for _, c := range colors {
+				var fields []string
+				for _, u := range usages[c.Name] {
+					if u != "" {
+						fields = append(fields, u)
+					}
+				}
+				if err := f.SetUsage(c.Name, fields); err != nil {
+					return fmt.Errorf("%s: %w", path, err)
+				}
+			}
+		}
+
+		formatted := f.Bytes()
+		accessors, err := csscolors.GenerateAccessors(dir, formatted)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		genPath := filepath.Join(dir, "colors_gen.go")
+		generated, _ := os.ReadFile(genPath)
+
+		for _, out := range []struct {
+			path          string
+			want, current []byte
+		}{{path, formatted, original}, {genPath, accessors, generated}} {
+			if bytes.Equal(out.want, out.current) {
+				continue
+			}
+			if *check {
+				unformatted = append(unformatted, out.path)
+				continue
+			}
+			if err := os.WriteFile(out.path, out.want, 0644); err != nil {
+				return err
+			}
+			fmt.Printf("Formatted %s\n", out.path)
+		}
+	}
+
+	if len(unformatted) > 0 {
+		return fmt.Errorf("not formatted: %s (run go run generate-theme.go fmt)", strings.Join(unformatted, ", "))
+	}
+	return nil
+}
+
+// lintCmd checks every variant against the lint rules and fails when any
+// error-level finding is left
+func lintCmd(args []string) error {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	variantName := fs.String("variant", "all", "variant name or slug, or \"all\"")
+	minSeverity := fs.String("severity", "warning", "lowest severity to report: info, warning or error")
+	list := fs.Bool("rules", false, "list the available rules and exit")
+	fs.Parse(args)
+
+	if *list {
+		for _, r := range lint.Rules() {
+			fmt.Printf("%-26s %-8s %s\n", r.ID, r.Severity, r.Description)
+		}
+		return nil
+	}
+
+	threshold, err := lint.ParseSeverity(*minSeverity)
+	if err != nil {
+		return err
+	}
+	selected := variants.All()
+	if *variantName != "all" {
+		v, ok := variants.Find(*variantName)
+		if !ok {
+			return fmt.Errorf("unknown variant %q", *variantName)
+		}
+		selected = []palette.ThemeVariant{v}
+	}
+
+	errors := 0
+	for _, v := range selected {
+		target := lint.Target{
+			Variant: v,
+			Style:   palette.GenerateThemeStyle(v.Name, v.Appearance, v.Palette).Style,
+			Desktop: variants.Desktop(v),
+		}
+		for _, f := range lint.Run(target, lint.Applicable(lint.ParseSuppressions(variants.PaletteSource(v)), variants.PaletteFuncs(v), v.Palette)) {
+			if f.Severity == lint.Error {
+				errors++
+			}
+			if f.Severity >= threshold {
+				fmt.Printf("%s: %s\n", v.Name, f)
+			}
+		}
+	}
+	if errors > 0 {
+		return fmt.Errorf("%d lint errors", errors)
+	}
+	return nil
+}
+
+// overridesCmd turns a file of palette field overrides into the
+// experimental.theme_overrides block for Zed's settings.json
+func overridesCmd(args []string) error {
+	fs := flag.NewFlagSet("overrides", flag.ExitOnError)
+	variantName := fs.String("variant", "tron-legacy", "variant name or slug the overrides apply to")
+	file := fs.String("file", "", "JSON file mapping TronThemePalette fields to colors or colors.css variables")
+	outPath := fs.String("out", "", "write the settings.json snippet here instead of stdout")
+	fs.Parse(args)
+
+	if *file == "" {
+		return fmt.Errorf("-file is required")
+	}
+	v, ok := variants.Find(*variantName)
+	if !ok {
+		return fmt.Errorf("unknown variant %q", *variantName)
+	}
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	fields, err := export.LoadOverrides(data, variants.ColorsCSS(v))
+	if err != nil {
+		return err
+	}
+	o, err := export.NewThemeOverrides(v, fields)
+	if err != nil {
+		return err
+	}
+
+	// The summary goes to stderr so stdout can be piped into settings.json
+	for _, field := range palette.ColorFields() {
+		if keys, ok := o.Affected[field]; ok {
+			if len(keys) == 0 {
+				fmt.Fprintf(os.Stderr, "%s: no change from %s\n", field, v.Name)
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "%s → %s\n", field, strings.Join(keys, ", "))
+		}
+	}
+
+	if *outPath == "" {
+		_, err := os.Stdout.Write(o.Settings)
+		return err
+	}
+	return os.WriteFile(*outPath, o.Settings, 0644)
+}
+
+// ramp prints an evenly spaced OKLCH ramp as CSS variables, or without an
+// anchor reports how the ramps declared in colors.css deviate from even ones
+func ramp(args []string) error {
+	fs := flag.NewFlagSet("ramp", flag.ExitOnError)
+	anchor := fs.String("anchor", "", "anchor color supplying hue and chroma, e.g. #647c9b (omit to report on colors.css)")
+	opts := derive.RampOptions{}
+	fs.StringVar(&opts.Name, "name", "gray", "variable name prefix")
+	fs.IntVar(&opts.Count, "count", 11, "number of steps")
+	fs.Float64Var(&opts.MinL, "min-l", 0.2, "OKLCH lightness of the darkest step")
+	fs.Float64Var(&opts.MaxL, "max-l", 0.95, "OKLCH lightness of the lightest step")
+	fs.Parse(args)
+
+	if *anchor != "" {
+		c, err := colormath.ParseHex(*anchor)
+		if err != nil {
+			return err
+		}
+		opts.Anchor = c
+		steps, err := derive.GenerateRamp(opts)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(derive.RampCSS(opts.Name, steps)))
+		return nil
+	}
+
+	for _, css := range []struct {
+		name string
+		data []byte
+	}{{"dark", dark.ColorsCSS()}, {"light", light.ColorsCSS()}} {
+		colors, err := csscolors.LoadColors(css.data)
+		if err != nil {
+			return err
+		}
+		ramps, err := csscolors.LoadRamps(css.data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", css.name, err)
+		}
+		for _, r := range ramps {
+			devs, err := derive.RampDeviations(r, colors)
+			if err != nil {
+				return fmt.Errorf("%s: %w", css.name, err)
+			}
+			fmt.Printf("%s/colors.css: ", css.name)
+			derive.WriteRampReport(os.Stdout, r.Name, devs)
+		}
+	}
+	return nil
+}
+
+// tokensCmd writes every variant as W3C design tokens and as a Style
+// Dictionary project
+func tokensCmd(args []string) error {
+	fs := flag.NewFlagSet("tokens", flag.ExitOnError)
+	outDir := fs.String("out", "../dist/tokens", "output directory")
+	fs.Parse(args)
+
+	for _, v := range variants.All() {
+		data, err := export.DTCG(v, variants.ColorsCSS(v))
+		if err != nil {
+			return fmt.Errorf("%s: %w", v.Name, err)
+		}
+		files, err := export.StyleDictionary(v, variants.ColorsCSS(v))
+		if err != nil {
+			return fmt.Errorf("%s: %w", v.Name, err)
+		}
+		if err := os.MkdirAll(*outDir, 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(*outDir, v.Slug()+".tokens.json"), data, 0644); err != nil {
+			return fmt.Errorf("writing %s tokens: %w", v.Name, err)
+		}
+
+		for name, content := range files {
+			path := filepath.Join(*outDir, "style-dictionary", v.Slug(), name)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(path, content, 0644); err != nil {
+				return fmt.Errorf("writing %s: %w", path, err)
+			}
+		}
+	}
+
+	fmt.Printf("Design tokens written to %s\n", *outDir)
+	return nil
+}
+
+// exportCmd runs registered exporters over the variants
+func exportCmd(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	formats := fs.String("format", "all", "comma-separated exporter names, or \"all\"")
+	variantName := fs.String("variant", "all", "variant name or slug, or \"all\"")
+	outDir := fs.String("out", "../dist/export", "output directory")
+	list := fs.Bool("list", false, "list the exporters and exit")
+	templateDir := fs.String("templates", "", "directory of *.tmpl text/template files to register as exporters; -format defaults to just these")
+	fs.Parse(args)
+
+	var templateNames []string
+	if *templateDir != "" {
+		templates, err := export.LoadTemplates(*templateDir)
+		if err != nil {
+			return err
+		}
+		if len(templates) == 0 {
+			return fmt.Errorf("no *.tmpl files in %s", *templateDir)
+		}
+		for _, e := range templates {
+			if _, ok := export.Lookup(e.Name()); ok {
+				return fmt.Errorf("%s.tmpl: an exporter named %q already exists", strings.TrimPrefix(e.Extension(), "."), e.Name())
+			}
+			export.Register(e)
+			templateNames = append(templateNames, e.Name())
+		}
+		formatSet := false
+		fs.Visit(func(f *flag.Flag) { formatSet = formatSet || f.Name == "format" })
+		if !formatSet {
+			*formats = strings.Join(templateNames, ",")
+		}
+	}
+
+	if *list {
+		for _, e := range export.Exporters() {
+			fmt.Printf("%-18s *%s\n", e.Name(), e.Extension())
+		}
+		return nil
+	}
+
+	selected := variants.All()
+	if *variantName != "all" {
+		v, ok := variants.Find(*variantName)
+		if !ok {
+			return fmt.Errorf("unknown variant %q", *variantName)
+		}
+		selected = []palette.ThemeVariant{v}
+	}
+
+	names := strings.Split(*formats, ",")
+	if *formats == "all" {
+		names = nil
+		for _, e := range export.Exporters() {
+			names = append(names, e.Name())
+		}
+	}
+	if err := runExporters(names, selected, *outDir); err != nil {
+		return err
+	}
+	fmt.Printf("Exports written to %s\n", *outDir)
+	return nil
+}
+
+// runExporters writes <slug><extension> for each named exporter and variant
+func runExporters(names []string, selected []palette.ThemeVariant, outDir string) error {
+	var exporters []export.Exporter
+	for _, name := range names {
+		e, ok := export.Lookup(strings.TrimSpace(name))
+		if !ok {
+			return fmt.Errorf("unknown exporter %q (see export -list)", name)
+		}
+		exporters = append(exporters, e)
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return err
+	}
+	for _, v := range selected {
+		for _, e := range exporters {
+			data, err := export.Run(e, v, variants.ColorsCSS(v))
+			if err != nil {
+				return fmt.Errorf("%s %s: %w", e.Name(), v.Name, err)
+			}
+			name := v.Slug() + e.Extension()
+			if err := os.WriteFile(filepath.Join(outDir, name), data, 0644); err != nil {
+				return fmt.Errorf("writing %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// highlighters writes a Chroma, Pygments and highlight.js theme for every
+// variant
+func highlighters(args []string) error {
+	fs := flag.NewFlagSet("highlighters", flag.ExitOnError)
+	outDir := fs.String("out", "../dist/highlighters", "output directory")
+	fs.Parse(args)
+
+	if err := runExporters([]string{"chroma", "pygments", "hljs"}, variants.All(), *outDir); err != nil {
+		return err
+	}
+	fmt.Printf("Syntax highlighter themes written to %s\n", *outDir)
+	return nil
+}
+
+// terminals writes a Windows Terminal, iTerm2 and Xresources color scheme
+// for every variant
+func terminals(args []string) error {
+	fs := flag.NewFlagSet("terminals", flag.ExitOnError)
+	outDir := fs.String("out", "../dist/terminals", "output directory")
+	fs.Parse(args)
+
+	if err := runExporters([]string{"windows-terminal", "iterm2", "xresources"}, variants.All(), *outDir); err != nil {
+		return err
+	}
+	fmt.Printf("Terminal color schemes written to %s\n", *outDir)
+	return nil
+}
+
+// watchDirs are the directories, relative to tools/, whose sources end up
+// in the theme: colors.css and palette.go files, the generator, derived
+// variants and the extension manifest
+var watchDirs = []string{".", "dark", "light", "palette", "derive", "variants", "cvd", "colormath", ".."}
+
+// watchCmd regenerates the theme whenever its sources change and installs
+// it into Zed's themes directory
+func watchCmd(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	defaultDir, err := watch.DefaultThemesDir()
+	if err != nil {
+		return err
+	}
+	themesDir := fs.String("dir", defaultDir, "themes directory to install into")
+	debounce := fs.Duration("debounce", 200*time.Millisecond, "wait this long after a change before rebuilding")
+	fs.Parse(args)
+
+	// colors.css and palette.go are compiled into this binary, so each
+	// rebuild compiles a fresh generator; the build cache keeps that to the
+	// packages that changed
+	tmp, err := os.MkdirTemp("", "tron-legacy-watch")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	bin := filepath.Join(tmp, "generate-theme")
+	out := filepath.Join(tmp, "tron-legacy.json")
+
+	build := func(changed []string) ([]byte, error) {
+		regenerate := len(changed) == 0
+		for _, path := range changed {
+			regenerate = regenerate || strings.HasSuffix(path, ".css")
+		}
+		if regenerate {
+			if err := runQuiet("go", "generate", "./dark", "./light"); err != nil {
+				return nil, err
+			}
+		}
+		if err := runQuiet("go", "build", "-o", bin, "generate-theme.go"); err != nil {
+			return nil, err
+		}
+		if err := runQuiet(bin, "generate", "-out", out); err != nil {
+			return nil, err
+		}
+		if err := runQuiet(bin, "lint", "-severity", "error"); err != nil {
+			return nil, err
+		}
+		return os.ReadFile(out)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	fmt.Printf("Watching for changes; installing into %s (Ctrl-C to stop)\n", *themesDir)
+	return watch.Run(ctx, watch.Config{
+		Dirs:     watchDirs,
+		Match:    watchMatch,
+		Debounce: *debounce,
+		Build:    build,
+		Install:  filepath.Join(*themesDir, "tron-legacy.json"),
+		Log:      os.Stdout,
+	})
+}
+
+// watchMatch selects the files a rebuild depends on. Generated accessors
+// are skipped since the rebuild itself rewrites them.
+func watchMatch(path string) bool {
+	name := filepath.Base(path)
+	switch {
+	case name == "extension.toml":
+		return true
+	case name == "colors_gen.go", strings.HasSuffix(name, "_test.go"):
+		return false
+	}
+	return strings.HasSuffix(name, ".css") || strings.HasSuffix(name, ".go")
+}
+
+// runQuiet runs a command, including its output in the error if it fails
+func runQuiet(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s %s: %w\n%s", filepath.Base(name), strings.Join(args, " "), err, bytes.TrimSpace(output))
+	}
+	return nil
+}
+
+// web writes the semantic CSS custom properties, SCSS maps and Tailwind
+// preset for websites
+func web(args []string) error {
+	fs := flag.NewFlagSet("web", flag.ExitOnError)
+	outDir := fs.String("out", "../dist/web", "output directory")
+	fs.Parse(args)
+
+	all := variants.All()
+	tailwind, err := export.Tailwind(all, dark.ColorsCSS(), light.ColorsCSS())
+	if err != nil {
+		return err
+	}
+	files := map[string][]byte{
+		"tron-legacy.css":    export.CSS(all),
+		"_tron-legacy.scss":  export.SCSS(all),
+		"tailwind.preset.js": tailwind,
+	}
+
+	if err := os.MkdirAll(*outDir, 0755); err != nil {
+		return err
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(*outDir, name), content, 0644); err != nil {
+			return fmt.Errorf("writing %s: %w", name, err)
+		}
+	}
+
+	fmt.Printf("Web exports written to %s\n", *outDir)
+	return nil
 }
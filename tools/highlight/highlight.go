@@ -0,0 +1,316 @@
+// Package highlight is a tiny, dependency-free tokenizer used to render
+// syntax-highlighted previews of the files in examples/. It is not a parser;
+// it only needs to look plausible enough to judge the theme colors.
+package highlight
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token is a run of text tagged with a Zed syntax highlight name
+// (e.g. "keyword", "string"). An empty Kind means plain editor text.
+type Token struct {
+	Text string
+	Kind string
+}
+
+// Language describes the lexical rules for a family of source files
+type Language struct {
+	Name          string
+	LineComments  []string
+	BlockComments [][2]string
+	Keywords      map[string]bool
+	Constants     map[string]bool
+}
+
+func words(s string) map[string]bool {
+	m := make(map[string]bool)
+	for _, w := range strings.Fields(s) {
+		m[w] = true
+	}
+	return m
+}
+
+var cLike = [][2]string{{"/*", "*/"}}
+
+var languages = map[string]Language{
+	".js": {
+		Name:          "JavaScript",
+		LineComments:  []string{"//"},
+		BlockComments: cLike,
+		Keywords:      words("async await break case catch class const continue default delete do else export extends finally for from function if import in instanceof let new of return static super switch this throw try typeof var void while yield"),
+		Constants:     words("true false null undefined NaN Infinity"),
+	},
+	".go": {
+		Name:          "Go",
+		LineComments:  []string{"//"},
+		BlockComments: cLike,
+		Keywords:      words("break case chan const continue default defer else fallthrough for func go goto if import interface map package range return select struct switch type var"),
+		Constants:     words("true false nil iota"),
+	},
+	".rs": {
+		Name:          "Rust",
+		LineComments:  []string{"//"},
+		BlockComments: cLike,
+		Keywords:      words("as async await break const continue crate dyn else enum extern fn for if impl in let loop match mod move mut pub ref return self Self static struct super trait type unsafe use where while"),
+		Constants:     words("true false None Some Ok Err"),
+	},
+	".py": {
+		Name:         "Python",
+		LineComments: []string{"#"},
+		Keywords:     words("and as assert async await break class continue def del elif else except finally for from global if import in is lambda nonlocal not or pass raise return try while with yield self"),
+		Constants:    words("True False None"),
+	},
+	".c": {
+		Name:          "C",
+		LineComments:  []string{"//"},
+		BlockComments: cLike,
+		Keywords:      words("auto break case char const continue default do double else enum extern float for goto if inline int long register return short signed sizeof static struct switch typedef union unsigned void volatile while #include #define #ifdef #ifndef #endif #if #else"),
+		Constants:     words("NULL true false"),
+	},
+	".cpp": {
+		Name:          "C++",
+		LineComments:  []string{"//"},
+		BlockComments: cLike,
+		Keywords:      words("auto bool break case catch char class const constexpr continue default delete do double else enum explicit extern float for friend if inline int long namespace new noexcept operator override private protected public return short signed sizeof static struct switch template this throw try typedef typename union unsigned using virtual void volatile while #include #define #ifdef #ifndef #endif #pragma"),
+		Constants:     words("nullptr true false NULL"),
+	},
+	".css": {
+		Name:          "CSS",
+		BlockComments: cLike,
+		Keywords:      words("@media @import @keyframes @font-face @supports !important"),
+	},
+	".html": {
+		Name:          "HTML",
+		BlockComments: [][2]string{{"<!--", "-->"}},
+	},
+}
+
+// ForFile returns the language rules for a file name, falling back to
+// C-like rules for unknown extensions.
+func ForFile(name string) Language {
+	if lang, ok := languages[strings.ToLower(filepath.Ext(name))]; ok {
+		return lang
+	}
+	return languages[".c"]
+}
+
+// Lines tokenizes source text and returns one token slice per line.
+// Block comments spanning several lines are tracked across line breaks.
+func (lang Language) Lines(src string) [][]Token {
+	src = strings.ReplaceAll(src, "\t", "    ")
+	lines := strings.Split(strings.TrimRight(src, "\n"), "\n")
+	out := make([][]Token, 0, len(lines))
+
+	openBlock := ""
+	for _, line := range lines {
+		var tokens []Token
+		tokens, openBlock = lang.line(line, openBlock)
+		out = append(out, tokens)
+	}
+	return out
+}
+
+// line tokenizes a single line. closing is the terminator of a block comment
+// left open by a previous line, if any.
+func (lang Language) line(line string, closing string) ([]Token, string) {
+	var tokens []Token
+	emit := func(text, kind string) {
+		if text == "" {
+			return
+		}
+		if n := len(tokens); n > 0 && tokens[n-1].Kind == kind {
+			tokens[n-1].Text += text
+			return
+		}
+		tokens = append(tokens, Token{Text: text, Kind: kind})
+	}
+
+	rest := line
+	if closing != "" {
+		end := strings.Index(rest, closing)
+		if end < 0 {
+			emit(rest, "comment")
+			return tokens, closing
+		}
+		emit(rest[:end+len(closing)], "comment")
+		rest = rest[end+len(closing):]
+	}
+
+	prev := ""
+	for rest != "" {
+		if prefix := lang.lineComment(rest); prefix != "" {
+			emit(rest, "comment")
+			return tokens, ""
+		}
+		if open, close := lang.blockComment(rest); open != "" {
+			end := strings.Index(rest[len(open):], close)
+			if end < 0 {
+				emit(rest, "comment")
+				return tokens, close
+			}
+			n := len(open) + end + len(close)
+			emit(rest[:n], "comment")
+			rest = rest[n:]
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(rest)
+		switch {
+		case r == '"' || r == '\'' || r == '`':
+			n := stringEnd(rest)
+			lang.emitString(rest[:n], emit)
+			rest = rest[n:]
+			prev = "string"
+		case unicode.IsDigit(r):
+			n := lang.wordEnd(rest)
+			emit(rest[:n], "number")
+			rest = rest[n:]
+			prev = "number"
+		case isWordStart(r):
+			n := lang.wordEnd(rest)
+			word := rest[:n]
+			rest = rest[n:]
+			kind := lang.classify(word, prev, rest)
+			emit(word, kind)
+			prev = "word"
+		case lang.Name == "HTML" && r == '<':
+			n := 1
+			if strings.HasPrefix(rest, "</") {
+				n = 2
+			}
+			emit(rest[:n], "punctuation.bracket")
+			rest = rest[n:]
+			tag := lang.wordEnd(rest)
+			emit(rest[:tag], "tag")
+			rest = rest[tag:]
+			prev = "tag"
+		default:
+			kind := "punctuation"
+			switch {
+			case strings.ContainsRune("()[]{}<>", r):
+				kind = "punctuation.bracket"
+			case strings.ContainsRune(",;:.", r):
+				kind = "punctuation.delimiter"
+			case strings.ContainsRune("+-*/%=!&|^~?", r):
+				kind = "operator"
+			case unicode.IsSpace(r):
+				kind = ""
+			}
+			emit(rest[:size], kind)
+			if r == '.' {
+				prev = "."
+			} else if !unicode.IsSpace(r) {
+				prev = kind
+			}
+			rest = rest[size:]
+		}
+	}
+	return tokens, ""
+}
+
+// emitString splits escape sequences out of string literals
+func (lang Language) emitString(s string, emit func(text, kind string)) {
+	for s != "" {
+		i := strings.IndexByte(s, '\\')
+		if i < 0 || i == len(s)-1 {
+			emit(s, "string")
+			return
+		}
+		emit(s[:i], "string")
+		emit(s[i:i+2], "string.escape")
+		s = s[i+2:]
+	}
+}
+
+// classify picks a highlight name for an identifier based on its spelling
+// and immediate neighbours.
+func (lang Language) classify(word, prev, rest string) string {
+	next := strings.TrimLeft(rest, " ")
+	switch {
+	case lang.Keywords[word]:
+		return "keyword"
+	case lang.Constants[word]:
+		return "boolean"
+	case lang.Name == "CSS" && strings.HasPrefix(next, ":") && !strings.HasPrefix(next, "::"):
+		return "property"
+	case lang.Name == "HTML" && strings.HasPrefix(next, "="):
+		return "attribute"
+	case strings.HasPrefix(next, "("):
+		return "function"
+	case prev == ".":
+		return "property"
+	case isConstantCase(word):
+		return "constant"
+	case unicode.IsUpper(rune(word[0])):
+		return "type"
+	case lang.Name == "HTML" || lang.Name == "CSS":
+		return ""
+	default:
+		return "variable"
+	}
+}
+
+func (lang Language) lineComment(s string) string {
+	for _, prefix := range lang.LineComments {
+		if strings.HasPrefix(s, prefix) {
+			return prefix
+		}
+	}
+	return ""
+}
+
+func (lang Language) blockComment(s string) (string, string) {
+	for _, pair := range lang.BlockComments {
+		if strings.HasPrefix(s, pair[0]) {
+			return pair[0], pair[1]
+		}
+	}
+	return "", ""
+}
+
+func isWordStart(r rune) bool {
+	return r == '_' || r == '$' || r == '@' || r == '#' || unicode.IsLetter(r)
+}
+
+// wordEnd returns the length of the identifier or number at the start of s.
+// CSS and HTML allow hyphens inside names.
+func (lang Language) wordEnd(s string) int {
+	hyphens := lang.Name == "CSS" || lang.Name == "HTML"
+	for i, r := range s {
+		if i > 0 && !(r == '_' || (hyphens && r == '-') || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return i
+		}
+	}
+	return len(s)
+}
+
+// stringEnd returns the length of the quoted string at the start of s,
+// or the rest of the line if it is unterminated.
+func stringEnd(s string) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+func isConstantCase(word string) bool {
+	if len(word) < 2 {
+		return false
+	}
+	for _, r := range word {
+		if unicode.IsLower(r) {
+			return false
+		}
+	}
+	return unicode.IsLetter(rune(word[0]))
+}
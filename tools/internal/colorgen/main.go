@@ -0,0 +1,44 @@
+// colorgen writes typed accessors for the variables in a colors.css file,
+// so palette code calls colors.Gray900() instead of MustGet("gray900").
+//
+// Usage (from a package directory, via go generate):
+//
+//	go run ../internal/colorgen -pkg dark -css colors.css -out colors_gen.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+)
+
+func main() {
+	pkg := flag.String("pkg", "", "package name of the generated file")
+	cssPath := flag.String("css", "colors.css", "colors.css to read")
+	outPath := flag.String("out", "colors_gen.go", "output Go file")
+	flag.Parse()
+
+	if *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*pkg, *cssPath, *outPath); err != nil {
+		fmt.Fprintf(os.Stderr, "colorgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(pkg, cssPath, outPath string) error {
+	css, err := os.ReadFile(cssPath)
+	if err != nil {
+		return err
+	}
+	src, err := csscolors.GenerateAccessors(pkg, css)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outPath, src, 0644)
+}
@@ -0,0 +1,199 @@
+// Code generated by internal/colorgen from colors.css; DO NOT EDIT.
+
+package light
+
+import "github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+
+// Colors has one method per colors.css variable
+type Colors struct {
+	m csscolors.ColorMap
+}
+
+// loadColors parses the embedded colors.css
+func loadColors() (Colors, error) {
+	m, err := csscolors.LoadColors(colorsCSS)
+	return Colors{m}, err
+}
+
+// Black is --black: #000000ff
+func (c Colors) Black() string { return c.m.MustGet("black") }
+
+// White is --white: #ffffffff
+func (c Colors) White() string { return c.m.MustGet("white") }
+
+// Transparent is --transparent: #00000000
+func (c Colors) Transparent() string { return c.m.MustGet("transparent") }
+
+// Shadow is --shadow: #00000020
+func (c Colors) Shadow() string { return c.m.MustGet("shadow") }
+
+// Gray50 is --gray50: #f5f7faff
+func (c Colors) Gray50() string { return c.m.MustGet("gray50") }
+
+// Gray100 is --gray100: #e8ecf2ff
+func (c Colors) Gray100() string { return c.m.MustGet("gray100") }
+
+// Gray125 is --gray125: #dfe5edff
+func (c Colors) Gray125() string { return c.m.MustGet("gray125") }
+
+// Gray150 is --gray150: #dce3edff
+func (c Colors) Gray150() string { return c.m.MustGet("gray150") }
+
+// Gray50Alpha95 is --gray50Alpha95: #f5f7faf2
+func (c Colors) Gray50Alpha95() string { return c.m.MustGet("gray50Alpha95") }
+
+// Gray50Frosted is --gray50Frosted: #f5f7fad9
+func (c Colors) Gray50Frosted() string { return c.m.MustGet("gray50Frosted") }
+
+// Gray100Alpha33 is --gray100Alpha33: #e8ecf255
+func (c Colors) Gray100Alpha33() string { return c.m.MustGet("gray100Alpha33") }
+
+// Gray100Alpha75 is --gray100Alpha75: #e8ecf2bf
+func (c Colors) Gray100Alpha75() string { return c.m.MustGet("gray100Alpha75") }
+
+// Gray100Alpha80 is --gray100Alpha80: #e8ecf2cc
+func (c Colors) Gray100Alpha80() string { return c.m.MustGet("gray100Alpha80") }
+
+// Gray100Alpha87 is --gray100Alpha87: #e8ecf2dd
+func (c Colors) Gray100Alpha87() string { return c.m.MustGet("gray100Alpha87") }
+
+// Gray125Alpha80 is --gray125Alpha80: #dfe5edcc
+func (c Colors) Gray125Alpha80() string { return c.m.MustGet("gray125Alpha80") }
+
+// Gray200 is --gray200: #d1dae6ff
+func (c Colors) Gray200() string { return c.m.MustGet("gray200") }
+
+// Gray300 is --gray300: #b8c5d6ff
+func (c Colors) Gray300() string { return c.m.MustGet("gray300") }
+
+// Gray400 is --gray400: #8a9db5ff
+func (c Colors) Gray400() string { return c.m.MustGet("gray400") }
+
+// Gray200Alpha40 is --gray200Alpha40: #d1dae666
+func (c Colors) Gray200Alpha40() string { return c.m.MustGet("gray200Alpha40") }
+
+// Gray500Alpha20 is --gray500Alpha20: #6b7e9633
+func (c Colors) Gray500Alpha20() string { return c.m.MustGet("gray500Alpha20") }
+
+// Gray300Alpha60 is --gray300Alpha60: #b8c5d699
+func (c Colors) Gray300Alpha60() string { return c.m.MustGet("gray300Alpha60") }
+
+// Gray500Alpha40 is --gray500Alpha40: #6b7e9666
+func (c Colors) Gray500Alpha40() string { return c.m.MustGet("gray500Alpha40") }
+
+// Gray500 is --gray500: #6b7e96ff
+func (c Colors) Gray500() string { return c.m.MustGet("gray500") }
+
+// Gray600 is --gray600: #526073ff
+func (c Colors) Gray600() string { return c.m.MustGet("gray600") }
+
+// Gray700 is --gray700: #2d3e4fff
+func (c Colors) Gray700() string { return c.m.MustGet("gray700") }
+
+// Gray800 is --gray800: #1a2530ff
+func (c Colors) Gray800() string { return c.m.MustGet("gray800") }
+
+// Gray900 is --gray900: #14191fff
+func (c Colors) Gray900() string { return c.m.MustGet("gray900") }
+
+// Gray500Alpha25 is --gray500Alpha25: #6b7e9640
+func (c Colors) Gray500Alpha25() string { return c.m.MustGet("gray500Alpha25") }
+
+// Gray600Alpha35 is --gray600Alpha35: #52607359
+func (c Colors) Gray600Alpha35() string { return c.m.MustGet("gray600Alpha35") }
+
+// Gray600Frosted is --gray600Frosted: #526073dd
+func (c Colors) Gray600Frosted() string { return c.m.MustGet("gray600Frosted") }
+
+// Gray700Frosted is --gray700Frosted: #2d3e4fee
+func (c Colors) Gray700Frosted() string { return c.m.MustGet("gray700Frosted") }
+
+// Blue600 is --blue600: #1a5f8aff
+func (c Colors) Blue600() string { return c.m.MustGet("blue600") }
+
+// Blue500 is --blue500: #267fb5ff
+func (c Colors) Blue500() string { return c.m.MustGet("blue500") }
+
+// Blue400 is --blue400: #3988c0ff
+func (c Colors) Blue400() string { return c.m.MustGet("blue400") }
+
+// Blue200 is --blue200: #0099ccff
+func (c Colors) Blue200() string { return c.m.MustGet("blue200") }
+
+// Blue300Alpha24 is --blue300Alpha24: #4a95b33d
+func (c Colors) Blue300Alpha24() string { return c.m.MustGet("blue300Alpha24") }
+
+// Blue200Alpha50 is --blue200Alpha50: #0099cc80
+func (c Colors) Blue200Alpha50() string { return c.m.MustGet("blue200Alpha50") }
+
+// Blue200Alpha60 is --blue200Alpha60: #0099cc99
+func (c Colors) Blue200Alpha60() string { return c.m.MustGet("blue200Alpha60") }
+
+// Blue200Alpha19 is --blue200Alpha19: #0099cc30
+func (c Colors) Blue200Alpha19() string { return c.m.MustGet("blue200Alpha19") }
+
+// Blue200Alpha10 is --blue200Alpha10: #0099cc1a
+func (c Colors) Blue200Alpha10() string { return c.m.MustGet("blue200Alpha10") }
+
+// Blue200Alpha13 is --blue200Alpha13: #0099cc22
+func (c Colors) Blue200Alpha13() string { return c.m.MustGet("blue200Alpha13") }
+
+// Blue200Alpha09 is --blue200Alpha09: #0099cc18
+func (c Colors) Blue200Alpha09() string { return c.m.MustGet("blue200Alpha09") }
+
+// Blue200Alpha20 is --blue200Alpha20: #0099cc33
+func (c Colors) Blue200Alpha20() string { return c.m.MustGet("blue200Alpha20") }
+
+// Blue200Alpha27 is --blue200Alpha27: #0099cc44
+func (c Colors) Blue200Alpha27() string { return c.m.MustGet("blue200Alpha27") }
+
+// Blue200Alpha40 is --blue200Alpha40: #0099cc66
+func (c Colors) Blue200Alpha40() string { return c.m.MustGet("blue200Alpha40") }
+
+// Green100 is --green100: #e6f7e3ff
+func (c Colors) Green100() string { return c.m.MustGet("green100") }
+
+// Green600 is --green600: #3a5f00ff
+func (c Colors) Green600() string { return c.m.MustGet("green600") }
+
+// Green500 is --green500: #5a8b2cff
+func (c Colors) Green500() string { return c.m.MustGet("green500") }
+
+// Green400 is --green400: #7aad3aff
+func (c Colors) Green400() string { return c.m.MustGet("green400") }
+
+// Yellow600 is --yellow600: #c9a000ff
+func (c Colors) Yellow600() string { return c.m.MustGet("yellow600") }
+
+// Yellow500 is --yellow500: #dbb200ff
+func (c Colors) Yellow500() string { return c.m.MustGet("yellow500") }
+
+// Orange700 is --orange700: #b35900ff
+func (c Colors) Orange700() string { return c.m.MustGet("orange700") }
+
+// Orange600 is --orange600: #cc7700ff
+func (c Colors) Orange600() string { return c.m.MustGet("orange600") }
+
+// Orange500 is --orange500: #e68a00ff
+func (c Colors) Orange500() string { return c.m.MustGet("orange500") }
+
+// Red100 is --red100: #ffe6e6ff
+func (c Colors) Red100() string { return c.m.MustGet("red100") }
+
+// Red600 is --red600: #cc0033ff
+func (c Colors) Red600() string { return c.m.MustGet("red600") }
+
+// Red500 is --red500: #d91e18ff
+func (c Colors) Red500() string { return c.m.MustGet("red500") }
+
+// Red400 is --red400: #e74c3cff
+func (c Colors) Red400() string { return c.m.MustGet("red400") }
+
+// Purple600 is --purple600: #6a56ccff
+func (c Colors) Purple600() string { return c.m.MustGet("purple600") }
+
+// Pink600 is --pink600: #d1459aff
+func (c Colors) Pink600() string { return c.m.MustGet("pink600") }
+
+// Pink500 is --pink500: #e589c4ff
+func (c Colors) Pink500() string { return c.m.MustGet("pink500") }
@@ -0,0 +1,212 @@
+// Package lint checks palettes and the generated theme style against rules
+// that a color can pass every type check and still break: unreadable text,
+// invisible scrollbar thumbs, hover states that look like the base state.
+//
+// Rules are registered with Register and identified by an ID. A finding can
+// be silenced from palette.go with a comment on, or directly above, the line
+// assigning the field:
+//
+//	Interactive: colors.Gray700(), // lint:ignore hover-distinct shared on purpose
+package lint
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+// Severity ranks how bad a finding is
+type Severity int
+
+const (
+	Info Severity = iota
+	Warning
+	Error
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Info:
+		return "info"
+	case Warning:
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+// ParseSeverity converts "info", "warning" or "error" to a Severity
+func ParseSeverity(s string) (Severity, error) {
+	for _, sev := range []Severity{Info, Warning, Error} {
+		if sev.String() == s {
+			return sev, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q", s)
+}
+
+// Target is one variant being linted
+type Target struct {
+	Variant palette.ThemeVariant
+	Style   *palette.ThemeStyle
+
+	// Desktop is what translucent window backgrounds are composited over
+	Desktop colormath.Color
+}
+
+// FieldsFor returns the palette fields holding a color, so findings about
+// generated style keys can be traced (and suppressed) back to palette.go
+func (t Target) FieldsFor(value string) []string {
+	var fields []string
+	for _, field := range palette.ColorFields() {
+		if v, _ := t.Variant.Palette.Get(field); v != "" && strings.EqualFold(v, value) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Finding is a single rule violation. Fields are the palette fields
+// involved; a suppression on any of them silences the finding.
+type Finding struct {
+	Rule     string
+	Severity Severity
+	Fields   []string
+	Message  string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s %s [%s]: %s", f.Severity, f.Rule, strings.Join(f.Fields, ", "), f.Message)
+}
+
+// Rule is a named check. Check reports findings without a rule ID or
+// severity; Run fills those in from the rule.
+type Rule struct {
+	ID          string
+	Severity    Severity
+	Description string
+	Check       func(t Target) []Finding
+}
+
+var rules []Rule
+
+// Register adds a rule to the set Run uses. IDs must be unique.
+func Register(r Rule) {
+	for _, existing := range rules {
+		if existing.ID == r.ID {
+			panic("lint: duplicate rule " + r.ID)
+		}
+	}
+	rules = append(rules, r)
+}
+
+// Rules returns the registered rules in registration order
+func Rules() []Rule {
+	return append([]Rule(nil), rules...)
+}
+
+// Suppressions maps palette fields to the rule IDs ignored for them
+type Suppressions map[string][]string
+
+var (
+	ignorePattern = regexp.MustCompile(`//\s*lint:ignore\s+([\w,-]+)`)
+	fieldPattern  = regexp.MustCompile(`^\s*(?:p\.)?([A-Z]\w*)\s*[:=]`)
+	funcPattern   = regexp.MustCompile(`^func\s+(\w+)\(`)
+)
+
+// ParseSuppressions reads lint:ignore comments from palette.go source,
+// keyed by the function they appear in, e.g. "GetFrostedPalette". A comment
+// applies to the field assigned on its own line or, when it stands alone,
+// on the next line. Several rules are separated by commas.
+func ParseSuppressions(src []byte) map[string]Suppressions {
+	all := map[string]Suppressions{}
+	var fn string
+	var pending []string
+	scanner := bufio.NewScanner(bytes.NewReader(src))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := funcPattern.FindStringSubmatch(line); m != nil {
+			fn, pending = m[1], nil
+			continue
+		}
+		var ids []string
+		if m := ignorePattern.FindStringSubmatch(line); m != nil {
+			ids = strings.Split(m[1], ",")
+		}
+
+		code, _, _ := strings.Cut(line, "//")
+		m := fieldPattern.FindStringSubmatch(code)
+		if m == nil {
+			// A comment line of its own carries over to the next
+			// assignment, but not one that comments out code
+			if loc := ignorePattern.FindStringIndex(strings.TrimSpace(line)); loc != nil && loc[0] == 0 {
+				pending = append(pending, ids...)
+			}
+			continue
+		}
+		if ids := append(pending, ids...); len(ids) > 0 {
+			if all[fn] == nil {
+				all[fn] = Suppressions{}
+			}
+			all[fn][m[1]] = append(all[fn][m[1]], ids...)
+		}
+		pending = nil
+	}
+	return all
+}
+
+// Applicable returns the suppressions that hold for p. funcs maps each
+// palette function to the palette it returns; a suppression written in a
+// function applies only while p's field still holds the color that function
+// assigns. An ignore on a frosted override therefore doesn't carry over to
+// the opaque variant, nor to a derived variant that changed the color.
+func Applicable(all map[string]Suppressions, funcs map[string]palette.TronThemePalette, p palette.TronThemePalette) Suppressions {
+	s := Suppressions{}
+	for fn, suppressions := range all {
+		base, ok := funcs[fn]
+		if !ok {
+			continue
+		}
+		for field, ids := range suppressions {
+			want, _ := base.Get(field)
+			if got, _ := p.Get(field); got != "" && strings.EqualFold(got, want) {
+				s[field] = append(s[field], ids...)
+			}
+		}
+	}
+	return s
+}
+
+// Suppressed reports whether the finding is ignored for one of its fields
+func (s Suppressions) Suppressed(f Finding) bool {
+	for _, field := range f.Fields {
+		for _, id := range s[field] {
+			if id == f.Rule {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Run applies every registered rule to the target and returns the findings
+// that are not suppressed, most severe first.
+func Run(t Target, suppress Suppressions) []Finding {
+	var findings []Finding
+	for _, r := range rules {
+		for _, f := range r.Check(t) {
+			f.Rule, f.Severity = r.ID, r.Severity
+			if !suppress.Suppressed(f) {
+				findings = append(findings, f)
+			}
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool { return findings[i].Severity > findings[j].Severity })
+	return findings
+}
@@ -0,0 +1,139 @@
+package lint
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/dark"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/variants"
+)
+
+func TestParseSuppressions(t *testing.T) {
+	src := []byte(`
+func GetPalette() palette.TronThemePalette {
+	return palette.TronThemePalette{
+		Background: colors.Gray900(),
+		Interactive: colors.Gray700(), // lint:ignore hover-distinct shared on purpose
+		// lint:ignore text-contrast,opaque-required
+		LineNumber: colors.Gray500(),
+		//lint:ignore hover-distinct
+		Border: colors.Blue500(),
+		// SelectionAlpha: colors.Gray700Alpha40(), // lint:ignore scrollbar-visible
+		Selection: colors.Gray700(),
+	}
+}
+
+func GetFrostedPalette() palette.TronThemePalette {
+	p := GetPalette()
+	p.Foreground = colors.Gray200Frosted() // lint:ignore opaque-required
+	return p
+}
+`)
+	got := ParseSuppressions(src)
+	want := map[string]Suppressions{
+		"GetPalette": {
+			"Interactive": {"hover-distinct"},
+			"LineNumber":  {"text-contrast", "opaque-required"},
+			"Border":      {"hover-distinct"},
+		},
+		"GetFrostedPalette": {
+			"Foreground": {"opaque-required"},
+		},
+	}
+	for fn, fields := range want {
+		for field, ids := range fields {
+			if !slices.Equal(got[fn][field], ids) {
+				t.Errorf("%s %s: suppressions = %v, want %v", fn, field, got[fn][field], ids)
+			}
+		}
+	}
+	for _, field := range []string{"Background", "Selection", "SelectionAlpha", "Foreground"} {
+		if len(got["GetPalette"][field]) > 0 {
+			t.Errorf("GetPalette %s: unexpected suppressions %v", field, got["GetPalette"][field])
+		}
+	}
+}
+
+func TestSuppressionsScopedToVariant(t *testing.T) {
+	frosted, _ := variants.Find("Tron Legacy Frosted")
+	all := ParseSuppressions(variants.PaletteSource(frosted))
+	funcs := variants.PaletteFuncs(frosted)
+	if got := Applicable(all, funcs, frosted.Palette)["Foreground"]; !slices.Contains(got, "opaque-required") {
+		t.Fatalf("frosted Foreground suppressions = %v, want opaque-required", got)
+	}
+
+	// The frosted-only ignore must not hide a translucent Foreground in
+	// the opaque variant
+	opaque, _ := variants.Find("Tron Legacy")
+	opaque.Palette.Foreground = "#c8d3e0cc"
+	var found bool
+	for _, f := range Run(target(opaque.Name, opaque.Appearance, opaque.Palette), Applicable(all, funcs, opaque.Palette)) {
+		if f.Rule == "opaque-required" && slices.Contains(f.Fields, "Foreground") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("opaque-required on Foreground was suppressed in Tron Legacy by a GetFrostedPalette ignore")
+	}
+}
+
+func target(name, appearance string, p palette.TronThemePalette) Target {
+	v := palette.ThemeVariant{Name: name, Appearance: appearance, Palette: p}
+	return Target{Variant: v, Style: palette.GenerateThemeStyle(v.Name, v.Appearance, v.Palette).Style, Desktop: variants.Desktop(v)}
+}
+
+func TestRulesCatchBrokenPalette(t *testing.T) {
+	p := dark.GetPalette()
+	p.Foreground = p.Surface
+	p.Error = "#ff0000aa"
+	p.SuccessSurface = "#80ff80ff"
+	p.ScrollbarThumb = "#ffffff05"
+	p.ScrollbarThumbHover = p.ScrollbarThumb
+
+	fired := map[string]bool{}
+	for _, f := range Run(target("Broken", "dark", p), nil) {
+		fired[f.Rule] = true
+	}
+	for _, r := range Rules() {
+		if !fired[r.ID] {
+			t.Errorf("rule %s did not fire", r.ID)
+		}
+	}
+
+	// Suppressing a field silences only that rule
+	suppress := Suppressions{"ScrollbarThumb": {"scrollbar-visible"}, "ScrollbarThumbHover": {"hover-distinct"}}
+	for _, f := range Run(target("Broken", "dark", p), suppress) {
+		if f.Rule == "scrollbar-visible" && slices.Contains(f.Fields, "ScrollbarThumb") {
+			t.Errorf("suppressed finding reported: %s", f)
+		}
+	}
+}
+
+func TestVariantsHaveNoErrors(t *testing.T) {
+	for _, v := range variants.All() {
+		tg := Target{Variant: v, Style: palette.GenerateThemeStyle(v.Name, v.Appearance, v.Palette).Style, Desktop: variants.Desktop(v)}
+		for _, f := range Run(tg, Applicable(ParseSuppressions(variants.PaletteSource(v)), variants.PaletteFuncs(v), v.Palette)) {
+			if f.Severity == Error {
+				t.Errorf("%s: %s", v.Name, f)
+			}
+		}
+	}
+}
+
+// Derived palettes only inherit the ignores of colors they left unchanged;
+// anything they compute has no palette.go line to carry a lint:ignore
+func TestDerivedFrostedHasNoWarnings(t *testing.T) {
+	for _, v := range variants.All() {
+		if !strings.Contains(v.Name, "Frosted ") {
+			continue
+		}
+		tg := Target{Variant: v, Style: palette.GenerateThemeStyle(v.Name, v.Appearance, v.Palette).Style, Desktop: variants.Desktop(v)}
+		for _, f := range Run(tg, Applicable(ParseSuppressions(variants.PaletteSource(v)), variants.PaletteFuncs(v), v.Palette)) {
+			if f.Severity >= Warning {
+				t.Errorf("%s: %s", v.Name, f)
+			}
+		}
+	}
+}
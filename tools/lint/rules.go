@@ -0,0 +1,212 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/derive"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+func init() {
+	Register(Rule{
+		ID:          "text-contrast",
+		Severity:    Error,
+		Description: "foreground text reaches WCAG AA (muted text 3:1) on every surface it is drawn on",
+		Check:       textContrast,
+	})
+	Register(Rule{
+		ID:          "opaque-required",
+		Severity:    Warning,
+		Description: "text, focus and cursor colors are opaque",
+		Check:       opaqueRequired,
+	})
+	Register(Rule{
+		ID:          "surface-behind-foreground",
+		Severity:    Warning,
+		Description: "*Surface colors sit between the background and their foreground counterpart",
+		Check:       surfaceBehindForeground,
+	})
+	Register(Rule{
+		ID:          "hover-distinct",
+		Severity:    Warning,
+		Description: "hover and active states differ from the state before them",
+		Check:       hoverDistinct,
+	})
+	Register(Rule{
+		ID:          "fill-readable",
+		Severity:    Error,
+		Description: "text stays readable over every generated player selection and status surface",
+		Check:       fillReadable,
+	})
+	Register(Rule{
+		ID:          "scrollbar-visible",
+		Severity:    Error,
+		Description: "scrollbar thumbs stand out from the track",
+		Check:       scrollbarVisible,
+	})
+}
+
+// mutedFields are secondary text held to the non-text 3:1 minimum
+var mutedFields = map[string]bool{"ForegroundMuted": true, "LineNumber": true, "Comment": true}
+
+func textContrast(t Target) []Finding {
+	p := t.Variant.Palette
+	base := colormath.MustParseHex(p.Background).Over(t.Desktop)
+
+	var findings []Finding
+	for _, field := range []string{"Foreground", "ForegroundStrong", "ForegroundMuted", "LineNumber"} {
+		value, _ := p.Get(field)
+		fg, err := colormath.ParseHex(value)
+		if err != nil {
+			continue
+		}
+		min := 4.5
+		if mutedFields[field] {
+			min = derive.NonText
+		}
+		for _, surface := range derive.TextBackgrounds {
+			sv, _ := p.Get(surface)
+			bg, err := colormath.ParseHex(sv)
+			if err != nil {
+				continue
+			}
+			bg = bg.Over(base)
+			if c := colormath.Contrast(fg.Over(bg), bg); c < min {
+				findings = append(findings, Finding{
+					Fields:  []string{field, surface},
+					Message: fmt.Sprintf("%s %s on %s %s has contrast %.2f, want %.1f", field, value, surface, sv, c, min),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+func opaqueRequired(t Target) []Finding {
+	p := t.Variant.Palette
+	var findings []Finding
+	for _, field := range append(derive.TextFields(), "BorderFocused") {
+		value, _ := p.Get(field)
+		if c, err := colormath.ParseHex(value); err == nil && !c.IsOpaque() {
+			findings = append(findings, Finding{
+				Fields:  []string{field},
+				Message: fmt.Sprintf("%s %s is translucent, so it shifts with whatever is behind it", field, value),
+			})
+		}
+	}
+	for i, player := range t.Style.Players {
+		if c, err := colormath.ParseHex(player.Cursor); err == nil && !c.IsOpaque() {
+			findings = append(findings, Finding{
+				Fields:  t.FieldsFor(player.Cursor),
+				Message: fmt.Sprintf("players[%d].cursor %s is translucent", i, player.Cursor),
+			})
+		}
+	}
+	return findings
+}
+
+// surfaceBehindForeground checks that status surfaces are further from the
+// text than the status foreground: darker on dark themes, lighter on light
+func surfaceBehindForeground(t Target) []Finding {
+	p := t.Variant.Palette
+	base := colormath.MustParseHex(p.Background).Over(t.Desktop)
+	light := t.Variant.Appearance == "light"
+
+	var findings []Finding
+	for _, field := range []string{"Error", "Success"} {
+		fv, _ := p.Get(field)
+		sv, _ := p.Get(field + "Surface")
+		fg, err := colormath.ParseHex(fv)
+		if err != nil {
+			continue
+		}
+		surface, err := colormath.ParseHex(sv)
+		if err != nil {
+			continue
+		}
+		fl, sl := fg.Over(base).OKLCH().L, surface.Over(base).OKLCH().L
+		if (light && sl <= fl) || (!light && sl >= fl) {
+			direction := "lighter"
+			if light {
+				direction = "darker"
+			}
+			findings = append(findings, Finding{
+				Fields:  []string{field + "Surface", field},
+				Message: fmt.Sprintf("%sSurface %s is %s than %s %s", field, sv, direction, field, fv),
+			})
+		}
+	}
+	return findings
+}
+
+func hoverDistinct(t Target) []Finding {
+	s := t.Style
+	pairs := []struct{ base, state, baseValue, stateValue string }{
+		{"element.background", "element.hover", s.ElementBackground, s.ElementHover},
+		{"element.hover", "element.active", s.ElementHover, s.ElementActive},
+		{"ghost_element.background", "ghost_element.hover", s.GhostElementBackground, s.GhostElementHover},
+		{"ghost_element.hover", "ghost_element.active", s.GhostElementHover, s.GhostElementActive},
+		{"scrollbar.thumb.background", "scrollbar.thumb.hover_background", s.ScrollbarThumbBackground, s.ScrollbarThumbHoverBackground},
+		{"scrollbar.thumb.hover_background", "scrollbar.thumb.active_background", s.ScrollbarThumbHoverBackground, s.ScrollbarThumbActiveBackground},
+	}
+
+	var findings []Finding
+	for _, pair := range pairs {
+		if pair.stateValue == "" || !strings.EqualFold(pair.baseValue, pair.stateValue) {
+			continue
+		}
+		findings = append(findings, Finding{
+			Fields:  t.FieldsFor(pair.stateValue),
+			Message: fmt.Sprintf("%s is the same as %s (%s)", pair.state, pair.base, pair.stateValue),
+		})
+	}
+	return findings
+}
+
+// thumbMinDeltaE is how far a resting scrollbar thumb must be from its track
+const thumbMinDeltaE = 5.0
+
+func scrollbarVisible(t Target) []Finding {
+	s := t.Style
+	editor, err := colormath.ParseHex(s.EditorBackground)
+	if err != nil {
+		return nil
+	}
+	editor = editor.Over(colormath.MustParseHex(t.Variant.Palette.Background).Over(t.Desktop))
+	track := editor
+	if c, err := colormath.ParseHex(s.ScrollbarTrackBackground); err == nil {
+		track = c.Over(editor)
+	}
+
+	var findings []Finding
+	for _, thumb := range []struct{ key, value string }{
+		{"scrollbar.thumb.background", s.ScrollbarThumbBackground},
+		{"scrollbar.thumb.hover_background", s.ScrollbarThumbHoverBackground},
+	} {
+		c, err := colormath.ParseHex(thumb.value)
+		if err != nil {
+			continue
+		}
+		if d := colormath.DeltaE(c.Over(track), track); d < thumbMinDeltaE {
+			findings = append(findings, Finding{
+				Fields:  t.FieldsFor(thumb.value),
+				Message: fmt.Sprintf("%s %s is ΔE %.1f from the track, want %.0f", thumb.key, thumb.value, d, thumbMinDeltaE),
+			})
+		}
+	}
+	return findings
+}
+
+func fillReadable(t Target) []Finding {
+	p := t.Variant.Palette
+	var findings []Finding
+	if _, err := palette.GeneratePlayers(p, palette.PlayerCount, t.Desktop); err != nil {
+		findings = append(findings, Finding{Fields: []string{"Foreground", "Player1"}, Message: err.Error()})
+	}
+	if _, err := palette.GenerateStatusTiers(p, t.Desktop); err != nil {
+		findings = append(findings, Finding{Fields: []string{"Foreground"}, Message: err.Error()})
+	}
+	return findings
+}
@@ -0,0 +1,10 @@
+package palette
+
+// SyntaxAliases lists syntax roles that intentionally share one color. Tron
+// draws the language's structure (keywords, types, markup tags) in the same
+// blue and everything that constructs a value in the same orange; any other
+// pair of roles is expected to look different.
+var SyntaxAliases = [][]string{
+	{"Keyword", "Type", "Tag"},
+	{"Constructor", "Enum", "Attribute"},
+}
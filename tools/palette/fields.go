@@ -0,0 +1,37 @@
+package palette
+
+import "reflect"
+
+// ColorFields returns the names of every single-color (string) field of
+// TronThemePalette in declaration order. BackgroundAppearance is a keyword,
+// not a color, and is skipped.
+func ColorFields() []string {
+	t := reflect.TypeOf(TronThemePalette{})
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Type.Kind() == reflect.String && f.Name != "BackgroundAppearance" {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
+
+// Get returns the value of a color field by name
+func (p TronThemePalette) Get(field string) (string, bool) {
+	v := reflect.ValueOf(p).FieldByName(field)
+	if !v.IsValid() || v.Kind() != reflect.String {
+		return "", false
+	}
+	return v.String(), true
+}
+
+// Set assigns a color field by name, reporting whether the field exists
+func (p *TronThemePalette) Set(field, value string) bool {
+	v := reflect.ValueOf(p).Elem().FieldByName(field)
+	if !v.IsValid() || v.Kind() != reflect.String {
+		return false
+	}
+	v.SetString(value)
+	return true
+}
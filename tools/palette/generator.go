@@ -1,5 +1,11 @@
 package palette
 
+import (
+	"strings"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+)
+
 // ThemeVariant represents a single theme variant with its metadata
 type ThemeVariant struct {
 	Name       string
@@ -7,6 +13,11 @@ type ThemeVariant struct {
 	Palette    TronThemePalette
 }
 
+// Slug returns a file and selector friendly name, e.g. "tron-legacy-light"
+func (v ThemeVariant) Slug() string {
+	return strings.ToLower(strings.Join(strings.Fields(v.Name), "-"))
+}
+
 // GenerateTheme generates the complete theme JSON structure with any number of variants
 func GenerateTheme(name string, author string, variants ...ThemeVariant) Theme {
 	themes := make([]Style, 0, len(variants))
@@ -25,6 +36,10 @@ func GenerateTheme(name string, author string, variants ...ThemeVariant) Theme {
 // GenerateThemeStyle generates the style portion of the theme
 // This function maps semantic palette values to theme properties
 func GenerateThemeStyle(name string, appearance string, p TronThemePalette) Style {
+	// Unreadable fills are kept here and reported by the lint
+	// fill-readable rule
+	desktop := Desktop(appearance)
+	tiers, _ := GenerateStatusTiers(p, desktop)
 	style := &ThemeStyle{
 		// Borders
 		Border:            p.Border,
@@ -148,8 +163,8 @@ func GenerateThemeStyle(name string, appearance string, p TronThemePalette) Styl
 
 		// Status colors
 		Conflict:           p.Accent,
-		ConflictBackground: p.VCSConflict,
-		ConflictBorder:     p.Accent,
+		ConflictBackground: tiers.Accent.Surface,
+		ConflictBorder:     tiers.Accent.Border,
 
 		Created:           p.Success,
 		CreatedBackground: p.SuccessSurface,
@@ -168,28 +183,28 @@ func GenerateThemeStyle(name string, appearance string, p TronThemePalette) Styl
 		HiddenBorder:     p.Comment,
 
 		Hint:           p.Hint,
-		HintBackground: p.Background,
-		HintBorder:     p.Hint,
+		HintBackground: tiers.Hint.Surface,
+		HintBorder:     tiers.Hint.Border,
 
 		Ignored:           p.Comment,
 		IgnoredBackground: p.Background,
 		IgnoredBorder:     p.Comment,
 
 		Info:           p.Info,
-		InfoBackground: p.Background,
-		InfoBorder:     p.Info,
+		InfoBackground: tiers.Info.Surface,
+		InfoBorder:     tiers.Info.Border,
 
 		Modified:           p.Warning,
-		ModifiedBackground: p.BackgroundElevated,
-		ModifiedBorder:     p.Warning,
+		ModifiedBackground: tiers.Warning.Surface,
+		ModifiedBorder:     tiers.Warning.Border,
 
 		Predictive:           p.Comment,
 		PredictiveBackground: p.Background,
 		PredictiveBorder:     p.TerminalPurple,
 
 		Renamed:           p.Type,
-		RenamedBackground: p.Background,
-		RenamedBorder:     p.Type,
+		RenamedBackground: tiers.Type.Surface,
+		RenamedBorder:     tiers.Type.Border,
 
 		Success:           p.Success,
 		SuccessBackground: p.SuccessSurface,
@@ -200,11 +215,11 @@ func GenerateThemeStyle(name string, appearance string, p TronThemePalette) Styl
 		UnreachableBorder:     p.Comment,
 
 		Warning:           p.Warning,
-		WarningBackground: p.Background,
-		WarningBorder:     p.Warning,
+		WarningBackground: tiers.Warning.Surface,
+		WarningBorder:     tiers.Warning.Border,
 
 		// Players and Syntax
-		Players: generatePlayers(p),
+		Players: generatePlayers(p, desktop),
 		Syntax:  generateSyntax(p),
 	}
 
@@ -284,49 +299,9 @@ func GenerateThemeStyle(name string, appearance string, p TronThemePalette) Styl
 }
 
 // generatePlayers generates the multiplayer cursor colors
-func generatePlayers(p TronThemePalette) []Player {
-	return []Player{
-		{
-			Cursor:     p.Type,
-			Background: p.Type,
-			Selection:  p.Player1,
-		},
-		{
-			Cursor:     p.Info,
-			Background: p.Info,
-			Selection:  p.Player2,
-		},
-		{
-			Cursor:     p.Success,
-			Background: p.Success,
-			Selection:  p.Player3,
-		},
-		{
-			Cursor:     p.Accent,
-			Background: p.Accent,
-			Selection:  p.Player4,
-		},
-		{
-			Cursor:     p.TerminalPurple,
-			Background: p.TerminalPurple,
-			Selection:  p.TerminalPurple,
-		},
-		{
-			Cursor:     p.Error,
-			Background: p.Error,
-			Selection:  p.ErrorSurface,
-		},
-		{
-			Cursor:     p.Type,
-			Background: p.Type,
-			Selection:  p.Border,
-		},
-		{
-			Cursor:     p.Keyword,
-			Background: p.Keyword,
-			Selection:  p.ForegroundMuted,
-		},
-	}
+func generatePlayers(p TronThemePalette, desktop colormath.Color) []Player {
+	players, _ := GeneratePlayers(p, PlayerCount, desktop)
+	return players
 }
 
 // generateSyntax generates the syntax highlighting rules
@@ -53,6 +53,7 @@ type TronThemePalette struct {
 
 	// Status Colors
 	// Pattern: Base colors = FOREGROUND colors for text/borders, Surface colors = BACKGROUND colors for surfaces
+	// Warning, Info, Hint, Accent and Type surfaces and borders are derived by GenerateStatusTiers
 	Error          string // Errors, deletions - FOREGROUND color for text/borders
 	ErrorSurface   string // Error backgrounds - BACKGROUND color for surfaces/panels
 	Warning        string // Warnings, modifications - FOREGROUND color for text/borders
@@ -140,7 +141,6 @@ type TronThemePalette struct {
 	// Note: Git diffs use FOREGROUND colors because they overlay on syntax-highlighted text
 
 	VCSModified string // Git diff modified lines - used as FOREGROUND color
-	VCSConflict string // Git conflict backgrounds - used as BACKGROUND color
 
 	// ==========================================================================
 	// UI Components
@@ -153,10 +153,7 @@ type TronThemePalette struct {
 	ScrollbarTrackBorder string
 
 	// Collaboration/Players
-	Player1       string // Was Player1Alpha
-	Player2       string // Was Player2Alpha
-	Player3       string // Was Player3Alpha
-	Player4       string // Was Player4Alpha
+	Player1       string // Local selection; other players are generated from Accents
 
 	// ==========================================================================
 	// Theme Properties
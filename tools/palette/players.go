@@ -0,0 +1,190 @@
+package palette
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+)
+
+// PlayerCount is how many multiplayer colors the theme defines. Zed cycles
+// through them when more collaborators join.
+const PlayerCount = 8
+
+const (
+	// cursorContrast is the non-text contrast minimum (WCAG 1.4.11) a
+	// cursor must reach against the editor background
+	cursorContrast = 3.0
+	// selectionAlpha is the starting opacity of a player's selection,
+	// lowered until text on top of it keeps selectionTextContrast
+	selectionAlpha    = 0.24
+	minSelectionAlpha = 0.08
+	// selectionTextContrast is WCAG AA for body text; palettes whose text
+	// already reaches AAA keep AAA on selections too
+	selectionTextContrast    = 4.5
+	selectionTextContrastAAA = 7.0
+	// minPlayerDeltaE is the worst-case CIEDE2000 an accent must keep from
+	// every cursor already taken; it matches cvd.MinDeltaE
+	minPlayerDeltaE = 4.0
+)
+
+// Desktop returns the plain desktop color translucent (frosted) backgrounds
+// are flattened over: black for dark appearances, white for light ones
+func Desktop(appearance string) colormath.Color {
+	if appearance == "light" {
+		return colormath.Color{R: 1, G: 1, B: 1, A: 1}
+	}
+	return colormath.Color{A: 1}
+}
+
+// editorBackdrop returns the opaque editor background, flattened over
+// desktop, and the text color over it
+func editorBackdrop(p TronThemePalette, desktop colormath.Color) (bg, fg colormath.Color) {
+	bg = colormath.MustParseHex(p.EditorBackground).Over(colormath.MustParseHex(p.Background).Over(desktop))
+	return bg, colormath.MustParseHex(p.Foreground).Over(bg)
+}
+
+// GeneratePlayers returns n multiplayer colors. Cursors are the Tron
+// accents, starting with Accents[0] for the local player and then always
+// the accent furthest from those already taken, so any first k players are
+// as far apart as the accents allow. Distance is the worst CIEDE2000 under
+// normal vision and each dichromacy, and accents within minPlayerDeltaE of
+// a taken one are skipped. Once the accents run out, the remaining players
+// are picked the same way from an OKLCH hue and lightness grid at the
+// accents' median chroma. Every cursor reaches the non-text contrast
+// minimum on the editor background.
+//
+// Selections are the cursor color made translucent, as opaque as possible
+// while text drawn over them keeps AA contrast (AAA for palettes whose text
+// is AAA). The local player keeps the palette's own Player1 selection,
+// which derived variants tint, under the same contrast rule. desktop is what
+// translucent backgrounds are flattened over, see Desktop.
+//
+// When text can't reach the target even over the faintest selection, the
+// players are still returned along with an error naming them.
+func GeneratePlayers(p TronThemePalette, n int, desktop colormath.Color) ([]Player, error) {
+	bg, fg := editorBackdrop(p, desktop)
+
+	var anchors []colormath.Color
+	for _, a := range p.Accents {
+		if c, err := colormath.ParseHex(a); err == nil {
+			anchors = append(anchors, colormath.EnsureContrast(c.Opaque(), bg, cursorContrast))
+		}
+	}
+
+	var cursors []colormath.Color
+	if len(anchors) > 0 && n > 0 {
+		cursors = append(cursors, anchors[0])
+	}
+	cursors = pickSeparated(cursors, anchors[min(1, len(anchors)):], n, minPlayerDeltaE)
+	if len(cursors) < n {
+		cursors = pickSeparated(cursors, playerGrid(anchors, bg), n, 0)
+	}
+
+	target := textContrast(fg, bg)
+	readable := func(fill colormath.Color) bool {
+		return colormath.Contrast(fg, fill) >= target
+	}
+
+	var unreadable []string
+	players := make([]Player, len(cursors))
+	for i, cursor := range cursors {
+		selection := cursor.WithAlpha(selectionAlpha)
+		if i == 0 && p.Player1 != "" {
+			selection = colormath.MustParseHex(p.Player1)
+		}
+		selection, ok := readableFill(selection, bg, readable)
+		if !ok {
+			unreadable = append(unreadable, fmt.Sprintf("players[%d]", i))
+		}
+		players[i] = Player{
+			Cursor:     cursor.Hex(),
+			Background: cursor.Hex(),
+			Selection:  selection.Hex(),
+		}
+	}
+	if len(unreadable) > 0 {
+		return players, fmt.Errorf("text misses %.1f:1 contrast over the faintest selection of %s", target, strings.Join(unreadable, ", "))
+	}
+	return players, nil
+}
+
+// pickSeparated adds candidates to chosen until it holds n colors, each
+// time taking the candidate whose nearest chosen color is furthest away by
+// colormath.WorstDeltaE. Candidates nearer than minDistance are never
+// taken.
+func pickSeparated(chosen, candidates []colormath.Color, n int, minDistance float64) []colormath.Color {
+	rest := append([]colormath.Color(nil), candidates...)
+	for len(chosen) < n && len(rest) > 0 {
+		best, bestD := -1, -1.0
+		for i, c := range rest {
+			d := math.Inf(1)
+			for _, other := range chosen {
+				d = math.Min(d, colormath.WorstDeltaE(c, other))
+			}
+			if d >= minDistance && d > bestD {
+				best, bestD = i, d
+			}
+		}
+		if best < 0 {
+			break
+		}
+		chosen = append(chosen, rest[best])
+		rest = append(rest[:best], rest[best+1:]...)
+	}
+	return chosen
+}
+
+// playerGrid returns generated cursor candidates: every 15° of OKLCH hue at
+// the accents' median lightness and a step lighter and darker, all at their
+// median chroma and reaching the cursor contrast on bg
+func playerGrid(anchors []colormath.Color, bg colormath.Color) []colormath.Color {
+	l, c := 0.7, 0.15
+	if len(anchors) > 0 {
+		var ls, cs []float64
+		for _, a := range anchors {
+			o := a.OKLCH()
+			ls, cs = append(ls, o.L), append(cs, o.C)
+		}
+		l, c = median(ls), median(cs)
+	}
+
+	var grid []colormath.Color
+	for _, dl := range []float64{0, -0.12, 0.12} {
+		for h := 0.0; h < 360; h += 15 {
+			o := colormath.OKLCH{L: math.Min(0.95, math.Max(0.3, l+dl)), C: c, H: h}
+			grid = append(grid, colormath.EnsureContrast(o.Color(1), bg, cursorContrast))
+		}
+	}
+	return grid
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	return sorted[len(sorted)/2]
+}
+
+// textContrast is the contrast text drawn in fg must keep over translucent
+// fills on bg: AA, or AAA when fg already reaches AAA on bg
+func textContrast(fg, bg colormath.Color) float64 {
+	if colormath.Contrast(fg, bg) >= selectionTextContrastAAA {
+		return selectionTextContrastAAA
+	}
+	return selectionTextContrast
+}
+
+// readableFill lowers the alpha of fill until readable accepts it
+// composited over bg, stopping at minSelectionAlpha. ok is false when even
+// the faintest fill is rejected.
+func readableFill(fill, bg colormath.Color, readable func(colormath.Color) bool) (_ colormath.Color, ok bool) {
+	for !readable(fill.Over(bg)) {
+		if fill.A <= minSelectionAlpha {
+			return fill, false
+		}
+		fill = fill.WithAlpha(math.Max(minSelectionAlpha, fill.A-0.01))
+	}
+	return fill, true
+}
@@ -0,0 +1,113 @@
+package palette
+
+import (
+	_ "embed"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"sync"
+)
+
+//go:embed palette.go
+var paletteSource []byte
+
+// Section is a group of TronThemePalette fields as laid out in palette.go.
+// Group is the banner heading (e.g. "Interactive Elements") and Title the
+// comment directly above the fields (e.g. "Borders").
+type Section struct {
+	Group  string
+	Title  string
+	Fields []string
+}
+
+var (
+	sectionsOnce sync.Once
+	sections     []Section
+)
+
+// Sections returns the TronThemePalette fields grouped by the comment
+// sections in palette.go, so tooling stays in sync with the struct layout.
+func Sections() []Section {
+	sectionsOnce.Do(func() {
+		var err error
+		sections, err = parseSections(paletteSource)
+		if err != nil {
+			panic(err)
+		}
+	})
+	return sections
+}
+
+func parseSections(src []byte) ([]Section, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "palette.go", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields *ast.FieldList
+	ast.Inspect(file, func(n ast.Node) bool {
+		if ts, ok := n.(*ast.TypeSpec); ok && ts.Name.Name == "TronThemePalette" {
+			if st, ok := ts.Type.(*ast.StructType); ok {
+				fields = st.Fields
+			}
+			return false
+		}
+		return true
+	})
+	if fields == nil {
+		return nil, nil
+	}
+
+	var out []Section
+	group := ""
+	prevEnd := fields.Opening
+	for _, field := range fields.List {
+		// Comment groups between the previous field and this one start new sections
+		for _, cg := range file.Comments {
+			if cg.Pos() <= prevEnd || cg.End() >= field.Pos() {
+				continue
+			}
+			// Skip trailing comments on the previous field's line
+			if fset.Position(cg.Pos()).Line == fset.Position(prevEnd).Line {
+				continue
+			}
+			text := cg.Text()
+			if strings.Contains(text, "====") {
+				// Fields directly under a banner are titled after the banner
+				group = firstLine(strings.ReplaceAll(text, "=", ""))
+				out = append(out, Section{Group: group, Title: group})
+				continue
+			}
+			out = append(out, Section{Group: group, Title: firstLine(text)})
+		}
+		prevEnd = field.End()
+
+		if len(out) == 0 {
+			out = append(out, Section{Group: group})
+		}
+		for _, name := range field.Names {
+			out[len(out)-1].Fields = append(out[len(out)-1].Fields, name.Name)
+		}
+	}
+
+	// Drop banners that were immediately followed by a titled section
+	sections := out[:0]
+	for _, s := range out {
+		if len(s.Fields) > 0 {
+			sections = append(sections, s)
+		}
+	}
+	return sections, nil
+}
+
+// firstLine returns the first non-empty line of a comment
+func firstLine(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,172 @@
+package palette
+
+import (
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+//go:embed generator.go
+var generatorSource []byte
+
+// StyleSource is where GenerateThemeStyle takes one style color from: a
+// palette field copied as is, or a generator computing the color from the
+// palette
+type StyleSource struct {
+	// Field is the palette field, e.g. "ForegroundMuted"
+	Field string
+	// Generator names the computation when the color isn't copied, e.g.
+	// "GeneratePlayers" or "GenerateStatusTiers(Warning)"
+	Generator string
+}
+
+var (
+	styleSourcesOnce sync.Once
+	styleSources     map[string]StyleSource
+)
+
+// StyleSources maps each style color GenerateThemeStyle sets to its
+// source, read from the assignments in generator.go so it can't drift from
+// them. Keys are JSON paths inside the style object joined by "/":
+// "text.muted", "syntax/comment.doc", "players/0/cursor". The map is shared
+// and must not be modified.
+func StyleSources() map[string]StyleSource {
+	styleSourcesOnce.Do(func() {
+		var err error
+		styleSources, err = parseStyleSources(generatorSource)
+		if err != nil {
+			panic(err)
+		}
+	})
+	return styleSources
+}
+
+func parseStyleSources(src []byte) (map[string]StyleSource, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "generator.go", src, 0)
+	if err != nil {
+		return nil, err
+	}
+	styleKeys := jsonKeys(reflect.TypeOf(ThemeStyle{}))
+	syntaxKeys := jsonKeys(reflect.TypeOf(SyntaxStyles{}))
+
+	sources := map[string]StyleSource{}
+	add := func(keys map[string]string, prefix, goField string, value ast.Expr) error {
+		key, ok := keys[goField]
+		if !ok {
+			return fmt.Errorf("generator.go assigns unknown style field %s", goField)
+		}
+		if source, ok := sourceOf(value); ok {
+			sources[prefix+key] = source
+		}
+		return nil
+	}
+
+	var walkErr error
+	ast.Inspect(file, func(n ast.Node) bool {
+		if walkErr != nil {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.CompositeLit:
+			// ThemeStyle{Text: p.Foreground, ...} and
+			// SyntaxStyles{Keyword: SyntaxStyle{Color: p.Keyword, ...}, ...}
+			switch typeName(n.Type) {
+			case "ThemeStyle":
+				for _, elt := range n.Elts {
+					if kv, ok := elt.(*ast.KeyValueExpr); ok {
+						walkErr = add(styleKeys, "", kv.Key.(*ast.Ident).Name, kv.Value)
+					}
+				}
+			case "SyntaxStyles":
+				for _, elt := range n.Elts {
+					kv, ok := elt.(*ast.KeyValueExpr)
+					if !ok {
+						continue
+					}
+					if color := syntaxColor(kv.Value); color != nil {
+						walkErr = add(syntaxKeys, "syntax/", kv.Key.(*ast.Ident).Name, color)
+					}
+				}
+			}
+		case *ast.AssignStmt:
+			// style.PanelIndentGuide = p.Border
+			if len(n.Lhs) != 1 || len(n.Rhs) != 1 {
+				break
+			}
+			if sel, ok := n.Lhs[0].(*ast.SelectorExpr); ok && isIdent(sel.X, "style") {
+				walkErr = add(styleKeys, "", sel.Sel.Name, n.Rhs[0])
+			}
+		}
+		return true
+	})
+	if walkErr != nil {
+		return nil, walkErr
+	}
+
+	for i := range PlayerCount {
+		for _, key := range jsonKeys(reflect.TypeOf(Player{})) {
+			sources[fmt.Sprintf("players/%d/%s", i, key)] = StyleSource{Generator: "GeneratePlayers"}
+		}
+	}
+	return sources, nil
+}
+
+// sourceOf reads the source of an assigned value: p.Field is the palette
+// field and tiers.Warning.Surface the warning status tier
+func sourceOf(value ast.Expr) (StyleSource, bool) {
+	sel, ok := value.(*ast.SelectorExpr)
+	if !ok {
+		return StyleSource{}, false
+	}
+	if isIdent(sel.X, "p") {
+		return StyleSource{Field: sel.Sel.Name}, true
+	}
+	if tier, ok := sel.X.(*ast.SelectorExpr); ok && isIdent(tier.X, "tiers") {
+		return StyleSource{Generator: "GenerateStatusTiers(" + tier.Sel.Name + ")"}, true
+	}
+	return StyleSource{}, false
+}
+
+// syntaxColor returns the Color of a SyntaxStyle{...} literal
+func syntaxColor(value ast.Expr) ast.Expr {
+	lit, ok := value.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok && isIdent(kv.Key, "Color") {
+			return kv.Value
+		}
+	}
+	return nil
+}
+
+func typeName(expr ast.Expr) string {
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+func isIdent(expr ast.Expr, name string) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == name
+}
+
+// jsonKeys maps the Go field names of a struct to their JSON keys
+func jsonKeys(t reflect.Type) map[string]string {
+	keys := map[string]string{}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			keys[f.Name] = name
+		}
+	}
+	return keys
+}
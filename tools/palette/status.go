@@ -0,0 +1,82 @@
+package palette
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+)
+
+// StatusFields are the status colors GenerateStatusTiers derives tiers for,
+// in StatusTiers order. Error and Success have hand-picked ErrorSurface and
+// SuccessSurface instead.
+var StatusFields = []string{"Warning", "Info", "Hint", "Accent", "Type"}
+
+const (
+	// statusSurfaceAlpha is the starting opacity of a status tint, lowered
+	// like a selection until text on top of it stays readable
+	statusSurfaceAlpha = 0.16
+	// statusBorderContrast is the non-text minimum (WCAG 1.4.11) a status
+	// border keeps against the tinted surface
+	statusBorderContrast = 3.0
+)
+
+// StatusTier is the background and border Zed draws behind a status color,
+// such as a warning diagnostic or a renamed file
+type StatusTier struct {
+	Surface string
+	Border  string
+}
+
+// StatusTiers holds a StatusTier for each of StatusFields
+type StatusTiers struct {
+	Warning StatusTier
+	Info    StatusTier
+	Hint    StatusTier
+	Accent  StatusTier
+	Type    StatusTier
+}
+
+// GenerateStatusTiers derives the status tiers from the status colors
+// themselves. The surface is the status color made translucent, as opaque
+// as possible while the palette's text keeps AA contrast (AAA for palettes
+// whose text is AAA) on the editor background, where diagnostics render.
+// The border is the status color with its OKLCH lightness pushed just far
+// enough to reach 3:1 against the surface, so pale status colors on light
+// backgrounds get a darker border. desktop is what translucent backgrounds
+// are flattened over, see Desktop.
+//
+// When text can't reach the target even over the faintest tint, the tiers
+// are still returned along with an error naming them.
+func GenerateStatusTiers(p TronThemePalette, desktop colormath.Color) (StatusTiers, error) {
+	bg, fg := editorBackdrop(p, desktop)
+	target := textContrast(fg, bg)
+
+	var unreadable []string
+	tier := func(field, value string) StatusTier {
+		c := colormath.MustParseHex(value)
+		surface, ok := readableFill(c.Opaque().WithAlpha(statusSurfaceAlpha), bg, func(fill colormath.Color) bool {
+			return colormath.Contrast(fg, fill) >= target
+		})
+		if !ok {
+			unreadable = append(unreadable, field)
+		}
+		// Check the border against the surface as written, alpha rounded
+		surface = colormath.MustParseHex(surface.Hex())
+		return StatusTier{
+			Surface: surface.Hex(),
+			Border:  colormath.EnsureContrast(c, surface.Over(bg), statusBorderContrast).Hex(),
+		}
+	}
+	tiers := StatusTiers{
+		Warning: tier("Warning", p.Warning),
+		Info:    tier("Info", p.Info),
+		Hint:    tier("Hint", p.Hint),
+		Accent:  tier("Accent", p.Accent),
+		Type:    tier("Type", p.Type),
+	}
+	if len(unreadable) > 0 {
+		return tiers, fmt.Errorf("text misses %.1f:1 contrast over the faintest %s surface", target, strings.Join(unreadable, ", "))
+	}
+	return tiers, nil
+}
@@ -1,5 +1,10 @@
 package palette
 
+import (
+	"reflect"
+	"strings"
+)
+
 // Theme represents the complete theme structure
 type Theme struct {
 	Schema string  `json:"$schema"`
@@ -315,3 +320,31 @@ type SyntaxStyles struct {
 	DiffPlus       SyntaxStyle `json:"diff.plus"`
 	DiffMinus      SyntaxStyle `json:"diff.minus"`
 }
+
+// Lookup returns the syntax style for a Zed highlight name such as "keyword" or "string.escape"
+func (s SyntaxStyles) Lookup(name string) (SyntaxStyle, bool) {
+	v := reflect.ValueOf(s)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("json") == name {
+			return v.Field(i).Interface().(SyntaxStyle), true
+		}
+	}
+	return SyntaxStyle{}, false
+}
+
+// Resolve looks up a highlight name, falling back to parent scopes the way
+// Zed does ("punctuation.bracket" → "punctuation")
+func (s SyntaxStyles) Resolve(name string) (SyntaxStyle, bool) {
+	for name != "" {
+		if st, ok := s.Lookup(name); ok {
+			return st, true
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return SyntaxStyle{}, false
+}
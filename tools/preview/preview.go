@@ -0,0 +1,296 @@
+// Package preview prints a 24-bit ANSI color preview of a theme variant so
+// the palette can be sanity checked from any truecolor terminal, e.g. over SSH.
+package preview
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/cvd"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/highlight"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/variants"
+)
+
+// Options controls the preview output
+type Options struct {
+	// Width is the terminal width in columns
+	Width int
+
+	// FileName and Source are shown as the syntax highlighting sample
+	FileName string
+	Source   []byte
+
+	// SampleLines limits how many lines of Source are printed
+	SampleLines int
+}
+
+const reset = "\x1b[0m"
+
+func fg(c colormath.Color) string {
+	n := c.NRGBA()
+	return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", n.R, n.G, n.B)
+}
+
+func bg(c colormath.Color) string {
+	n := c.NRGBA()
+	return fmt.Sprintf("\x1b[48;2;%d;%d;%dm", n.R, n.G, n.B)
+}
+
+// printer composites every color over the variant's background before
+// printing, since terminals cannot show alpha.
+type printer struct {
+	w        io.Writer
+	backdrop colormath.Color
+	opts     Options
+}
+
+// resolve parses a color and flattens any alpha over the backdrop
+func (p *printer) resolve(hex string) (colormath.Color, error) {
+	if hex == "" {
+		return p.backdrop, nil
+	}
+	c, err := colormath.ParseHex(hex)
+	if err != nil {
+		return colormath.Color{}, err
+	}
+	return c.Over(p.backdrop), nil
+}
+
+// ink picks black or white text for a label drawn on top of c
+func ink(c colormath.Color) colormath.Color {
+	black := colormath.Color{A: 1}
+	white := colormath.Color{R: 1, G: 1, B: 1, A: 1}
+	if colormath.Contrast(c, black) >= colormath.Contrast(c, white) {
+		return black
+	}
+	return white
+}
+
+// Write prints the complete preview for a variant: the raw CSS variables,
+// the semantic palette and a highlighted code sample.
+func Write(w io.Writer, variant palette.ThemeVariant, colorsCSS []byte, opts Options) error {
+	if opts.Width <= 0 {
+		opts.Width = 100
+	}
+
+	// Frosted backgrounds are themselves translucent, so flatten them over
+	// a plain desktop matching the appearance first.
+	desktop := variants.Desktop(variant)
+	background, err := colormath.ParseHex(variant.Palette.Background)
+	if err != nil {
+		return fmt.Errorf("background: %w", err)
+	}
+	p := &printer{w: w, backdrop: background.Over(desktop), opts: opts}
+
+	fmt.Fprintf(w, "\n%s%s %s (%s) %s\n", bg(p.backdrop), fg(ink(p.backdrop)), variant.Name, variant.Appearance, reset)
+
+	colors, err := csscolors.LoadColorList(colorsCSS)
+	if err != nil {
+		return err
+	}
+	if err := p.cssVariables(colors); err != nil {
+		return err
+	}
+	if err := p.semanticPalette(variant.Palette); err != nil {
+		return err
+	}
+	if err := p.colorVision(variant, desktop); err != nil {
+		return err
+	}
+	if len(opts.Source) > 0 {
+		if err := p.sample(variant); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// swatch is a single labelled color cell
+type swatch struct {
+	label string
+	hex   string
+}
+
+// grid prints swatches in as many columns as fit the terminal width
+func (p *printer) grid(swatches []swatch) error {
+	cellWidth := 0
+	for _, s := range swatches {
+		cellWidth = max(cellWidth, len(s.label), len(s.hex))
+	}
+	cellWidth += 2
+	columns := max(1, p.opts.Width/(cellWidth+1))
+
+	for start := 0; start < len(swatches); start += columns {
+		row := swatches[start:min(start+columns, len(swatches))]
+		var top, bottom strings.Builder
+		for _, s := range row {
+			c, err := p.resolve(s.hex)
+			if err != nil {
+				return fmt.Errorf("%s: %w", s.label, err)
+			}
+			label := fmt.Sprintf(" %-*s", cellWidth-1, s.label)
+			value := fmt.Sprintf(" %-*s", cellWidth-1, s.hex)
+			top.WriteString(bg(c) + fg(ink(c)) + label + reset + " ")
+			bottom.WriteString(bg(c) + fg(ink(c)) + value + reset + " ")
+		}
+		fmt.Fprintln(p.w, top.String())
+		fmt.Fprintln(p.w, bottom.String())
+	}
+	return nil
+}
+
+func (p *printer) heading(title string) {
+	fmt.Fprintf(p.w, "\n\x1b[1m%s%s\n", title, reset)
+}
+
+func (p *printer) cssVariables(colors []csscolors.NamedColor) error {
+	p.heading("colors.css")
+	swatches := make([]swatch, 0, len(colors))
+	for _, c := range colors {
+		swatches = append(swatches, swatch{label: "--" + c.Name, hex: c.Value})
+	}
+	return p.grid(swatches)
+}
+
+func (p *printer) semanticPalette(tp palette.TronThemePalette) error {
+	group := ""
+	for _, section := range palette.Sections() {
+		if section.Group != group {
+			group = section.Group
+			p.heading(group)
+		}
+
+		var swatches []swatch
+		for _, field := range section.Fields {
+			if field == "Accents" {
+				for i, accent := range tp.Accents {
+					swatches = append(swatches, swatch{label: fmt.Sprintf("Accents[%d]", i), hex: accent})
+				}
+				continue
+			}
+			value, ok := tp.Get(field)
+			if !ok || !strings.HasPrefix(value, "#") {
+				continue
+			}
+			swatches = append(swatches, swatch{label: field, hex: value})
+		}
+		if len(swatches) == 0 {
+			continue
+		}
+		if section.Title != section.Group {
+			fmt.Fprintf(p.w, "\x1b[2m%s%s\n", section.Title, reset)
+		}
+		if err := p.grid(swatches); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// colorVision shows every critical color pair as simulated for each color
+// vision deficiency, flagging pairs that fall below cvd.MinDeltaE.
+func (p *printer) colorVision(variant palette.ThemeVariant, desktop colormath.Color) error {
+	style := palette.GenerateThemeStyle(variant.Name, variant.Appearance, variant.Palette).Style
+	results, err := cvd.Audit(style, desktop)
+	if err != nil {
+		return err
+	}
+	p.heading("Color vision deficiency")
+
+	visions := append([]cvd.Deficiency{""}, cvd.Deficiencies...)
+	byPair := map[string]map[cvd.Deficiency]cvd.Result{}
+	labelWidth := 0
+	for _, r := range results {
+		if byPair[r.Pair.Name] == nil {
+			byPair[r.Pair.Name] = map[cvd.Deficiency]cvd.Result{}
+		}
+		byPair[r.Pair.Name][r.Deficiency] = r
+		labelWidth = max(labelWidth, len(r.Pair.Name))
+	}
+
+	header := fmt.Sprintf("%-*s", labelWidth, "")
+	for _, d := range visions {
+		if d == "" {
+			d = "normal"
+		}
+		header += fmt.Sprintf("  %-14s", d)
+	}
+	fmt.Fprintf(p.w, "\x1b[2m%s%s\n", header, reset)
+
+	for _, pair := range cvd.CriticalPairs(style) {
+		var b strings.Builder
+		fmt.Fprintf(&b, "%-*s", labelWidth, pair.Name)
+		for _, d := range visions {
+			r := byPair[pair.Name][d]
+			mark := " "
+			if r.DeltaE < cvd.MinDeltaE {
+				mark = "!"
+			}
+			fmt.Fprintf(&b, "  %s  %s  %s%s%5.1f", bg(r.SimA), bg(r.SimB), reset, mark, r.DeltaE)
+		}
+		fmt.Fprintln(p.w, b.String())
+	}
+	return nil
+}
+
+func (p *printer) sample(variant palette.ThemeVariant) error {
+	style := palette.GenerateThemeStyle(variant.Name, variant.Appearance, variant.Palette).Style
+	p.heading(p.opts.FileName)
+
+	editorBg, err := p.resolve(style.EditorBackground)
+	if err != nil {
+		return err
+	}
+	lineNumber, err := p.resolve(style.EditorLineNumber)
+	if err != nil {
+		return err
+	}
+	text, err := p.resolve(style.EditorForeground)
+	if err != nil {
+		return err
+	}
+
+	lines := highlight.ForFile(p.opts.FileName).Lines(string(p.opts.Source))
+	if p.opts.SampleLines > 0 && len(lines) > p.opts.SampleLines {
+		lines = lines[:p.opts.SampleLines]
+	}
+
+	for i, tokens := range lines {
+		var b strings.Builder
+		b.WriteString(bg(editorBg) + fg(lineNumber) + fmt.Sprintf(" %4d  ", i+1))
+		width := 7
+		for _, tok := range tokens {
+			c := text
+			attrs := ""
+			if st, ok := style.Syntax.Resolve(tok.Kind); ok {
+				if c, err = p.resolve(st.Color); err != nil {
+					return fmt.Errorf("syntax %s: %w", tok.Kind, err)
+				}
+				if st.FontStyle != nil && *st.FontStyle == "italic" {
+					attrs += "\x1b[3m"
+				}
+				if st.FontWeight != nil && *st.FontWeight >= 600 {
+					attrs += "\x1b[1m"
+				}
+			}
+			// Count runes, not bytes, so multi-byte text isn't cut mid
+			// character or padded short
+			tokText := tok.Text
+			if width+utf8.RuneCountInString(tokText) > p.opts.Width {
+				tokText = string([]rune(tokText)[:max(0, p.opts.Width-width)])
+			}
+			width += utf8.RuneCountInString(tokText)
+			b.WriteString(attrs + fg(c) + tokText + "\x1b[22;23m")
+		}
+		if pad := p.opts.Width - width; pad > 0 {
+			b.WriteString(strings.Repeat(" ", pad))
+		}
+		fmt.Fprintln(p.w, b.String()+reset)
+	}
+	return nil
+}
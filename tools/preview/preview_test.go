@@ -0,0 +1,64 @@
+package preview
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/cvd"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/dark"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/light"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+func TestPreviewListsEveryColor(t *testing.T) {
+	cases := []struct {
+		variant palette.ThemeVariant
+		css     []byte
+	}{
+		{palette.ThemeVariant{Name: "Tron Legacy Frosted", Appearance: "dark", Palette: dark.GetFrostedPalette()}, dark.ColorsCSS()},
+		{palette.ThemeVariant{Name: "Tron Legacy Light", Appearance: "light", Palette: light.GetPalette()}, light.ColorsCSS()},
+	}
+
+	for _, tc := range cases {
+		var out bytes.Buffer
+		opts := Options{FileName: "example.js", Source: []byte("const x = \"y\";\n")}
+		if err := Write(&out, tc.variant, tc.css, opts); err != nil {
+			t.Fatalf("%s: %v", tc.variant.Name, err)
+		}
+
+		colors, err := csscolors.LoadColorList(tc.css)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, c := range colors {
+			if !strings.Contains(out.String(), "--"+c.Name+" ") {
+				t.Errorf("%s: preview is missing CSS variable --%s", tc.variant.Name, c.Name)
+			}
+		}
+		for _, section := range palette.Sections() {
+			if !strings.Contains(out.String(), section.Group) {
+				t.Errorf("%s: preview is missing palette section %q", tc.variant.Name, section.Group)
+			}
+		}
+		for _, d := range cvd.Deficiencies {
+			if !strings.Contains(out.String(), string(d)) {
+				t.Errorf("%s: preview is missing %s simulation", tc.variant.Name, d)
+			}
+		}
+	}
+}
+
+func TestPreviewTruncatesByRune(t *testing.T) {
+	variant := palette.ThemeVariant{Name: "Tron Legacy", Appearance: "dark", Palette: dark.GetPalette()}
+	var out bytes.Buffer
+	opts := Options{Width: 22, FileName: "example.js", Source: []byte("const s = \"ünïcödé ünïcödé ünïcödé\";\n")}
+	if err := Write(&out, variant, dark.ColorsCSS(), opts); err != nil {
+		t.Fatal(err)
+	}
+	if !utf8.Valid(out.Bytes()) {
+		t.Error("preview cut a multi-byte character")
+	}
+}
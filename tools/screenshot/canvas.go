@@ -0,0 +1,124 @@
+package screenshot
+
+import (
+	"image"
+	"math"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+)
+
+// canvas is an RGBA image that blends every draw call with source-over
+// compositing, so translucent theme colors stack the way they do in Zed.
+type canvas struct {
+	img *image.NRGBA
+}
+
+func newCanvas(w, h int) *canvas {
+	return &canvas{img: image.NewNRGBA(image.Rect(0, 0, w, h))}
+}
+
+func (cv *canvas) blend(x, y int, c colormath.Color) {
+	if !(image.Point{x, y}.In(cv.img.Rect)) || c.A <= 0 {
+		return
+	}
+	i := cv.img.PixOffset(x, y)
+	pix := cv.img.Pix[i : i+4 : i+4]
+	if c.A >= 1 {
+		nc := c.NRGBA()
+		pix[0], pix[1], pix[2], pix[3] = nc.R, nc.G, nc.B, nc.A
+		return
+	}
+	dst := colormath.Color{
+		R: float64(pix[0]) / 255,
+		G: float64(pix[1]) / 255,
+		B: float64(pix[2]) / 255,
+		A: float64(pix[3]) / 255,
+	}
+	nc := c.Over(dst).NRGBA()
+	pix[0], pix[1], pix[2], pix[3] = nc.R, nc.G, nc.B, nc.A
+}
+
+// fill blends c over the rectangle r
+func (cv *canvas) fill(r image.Rectangle, c colormath.Color) {
+	r = r.Intersect(cv.img.Rect)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			cv.blend(x, y, c)
+		}
+	}
+}
+
+// hline and vline draw 1px lines
+func (cv *canvas) hline(x0, x1, y int, c colormath.Color) {
+	cv.fill(image.Rect(x0, y, x1, y+1), c)
+}
+
+func (cv *canvas) vline(x, y0, y1 int, c colormath.Color) {
+	cv.fill(image.Rect(x, y0, x+1, y1), c)
+}
+
+// disc draws a filled circle with a soft 1px edge
+func (cv *canvas) disc(cx, cy, radius int, c colormath.Color) {
+	r := float64(radius)
+	for y := cy - radius - 1; y <= cy+radius+1; y++ {
+		for x := cx - radius - 1; x <= cx+radius+1; x++ {
+			dx, dy := float64(x-cx), float64(y-cy)
+			d := r + 0.5 - math.Sqrt(dx*dx+dy*dy)
+			if d <= 0 {
+				continue
+			}
+			if d > 1 {
+				d = 1
+			}
+			cv.blend(x, y, c.WithAlpha(c.A*d))
+		}
+	}
+}
+
+// squiggle draws a diagnostic underline between x0 and x1
+func (cv *canvas) squiggle(x0, x1, y int, c colormath.Color) {
+	for x := x0; x < x1; x++ {
+		offset := []int{0, 1, 2, 1}[(x-x0)%4]
+		cv.blend(x, y+offset, c)
+	}
+}
+
+// textStyle selects the font face and shear used for a run of text
+type textStyle struct {
+	bold   bool
+	italic bool
+}
+
+// text draws s with its cell-aligned top-left corner at x, y and returns the
+// x position after the last character.
+func (cv *canvas) text(x, y int, s string, c colormath.Color, style textStyle) int {
+	loadFaces()
+	f := regularFace
+	if style.bold {
+		f = boldFace
+	}
+	for _, r := range s {
+		for gy := 0; gy < f.cellH; gy++ {
+			shear := 0
+			if style.italic {
+				// Lean glyphs by roughly 12 degrees around the baseline
+				shear = (f.cellH*3/4 - gy) / 5
+			}
+			for gx := 0; gx < f.cellW; gx++ {
+				a := f.coverage(r, gx, gy)
+				if a == 0 {
+					continue
+				}
+				cv.blend(x+gx+shear, y+gy, c.WithAlpha(c.A*float64(a)/255))
+			}
+		}
+		x += f.cellW
+	}
+	return x
+}
+
+// cellSize returns the monospace cell dimensions
+func cellSize() (int, int) {
+	loadFaces()
+	return regularFace.cellW, regularFace.cellH
+}
@@ -0,0 +1,75 @@
+package screenshot
+
+import (
+	"bytes"
+	"embed"
+	"image"
+	"image/draw"
+	"image/png"
+	"sync"
+)
+
+// The fonts are found by file name, see internal/fontgen; set FONTGEN_DIR
+// to regenerate from a font installed elsewhere.
+//
+//go:generate go run ./internal/fontgen -ttf DejaVuSansMono.ttf -size 14 -out fonts/regular.png
+//go:generate go run ./internal/fontgen -ttf DejaVuSansMono-Bold.ttf -size 14 -out fonts/bold.png
+
+//go:embed fonts/*.png
+var fontFiles embed.FS
+
+const (
+	atlasFirstChar = 0x20
+	atlasColumns   = 16
+	atlasRows      = 6
+)
+
+// face is a fixed-cell bitmap font loaded from a glyph atlas
+type face struct {
+	atlas *image.Gray
+	cellW int
+	cellH int
+}
+
+var (
+	facesOnce   sync.Once
+	regularFace *face
+	boldFace    *face
+)
+
+func loadFaces() {
+	facesOnce.Do(func() {
+		regularFace = mustLoadFace("fonts/regular.png")
+		boldFace = mustLoadFace("fonts/bold.png")
+	})
+}
+
+func mustLoadFace(name string) *face {
+	data, err := fontFiles.ReadFile(name)
+	if err != nil {
+		panic(err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		panic(err)
+	}
+	gray := image.NewGray(img.Bounds())
+	draw.Draw(gray, gray.Bounds(), img, img.Bounds().Min, draw.Src)
+	return &face{
+		atlas: gray,
+		cellW: gray.Bounds().Dx() / atlasColumns,
+		cellH: gray.Bounds().Dy() / atlasRows,
+	}
+}
+
+// coverage returns the glyph coverage (0-255) of r at cell position x, y.
+// Characters outside the atlas render as '?'.
+func (f *face) coverage(r rune, x, y int) uint8 {
+	i := int(r) - atlasFirstChar
+	if i < 0 || i >= atlasColumns*atlasRows {
+		i = '?' - atlasFirstChar
+	}
+	ox := (i % atlasColumns) * f.cellW
+	oy := (i / atlasColumns) * f.cellH
+	return f.atlas.Pix[(oy+y)*f.atlas.Stride+ox+x]
+}
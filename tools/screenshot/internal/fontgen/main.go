@@ -0,0 +1,527 @@
+// fontgen rasterizes the printable ASCII range of a monospace TrueType font
+// into a grayscale glyph atlas for the screenshot renderer.
+//
+// The atlas is a 16x6 grid of equally sized cells, one per character from
+// 0x20 to 0x7f. Each cell is one advance wide and one line (ascent + descent)
+// tall, so the renderer can recover all metrics from the image size alone.
+//
+// Usage:
+//
+//	go run ./internal/fontgen -ttf DejaVuSansMono.ttf -size 14 -out fonts/regular.png
+//
+// A -ttf without a directory is looked up in -fontdir, which defaults to
+// $FONTGEN_DIR, and then in the usual system font directories.
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	firstChar = 0x20
+	columns   = 16
+	rows      = 6
+
+	// Subsamples per pixel used for anti-aliasing
+	subX = 16
+	subY = 5
+)
+
+func main() {
+	ttfPath := flag.String("ttf", "", "path or file name of a monospace TrueType font")
+	fontDir := flag.String("fontdir", os.Getenv("FONTGEN_DIR"), "directory searched for a -ttf without one (default $FONTGEN_DIR)")
+	size := flag.Float64("size", 14, "font size in pixels")
+	outPath := flag.String("out", "", "output PNG path")
+	flag.Parse()
+
+	if *ttfPath == "" || *outPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ttf, err := findFont(*ttfPath, *fontDir)
+	if err == nil {
+		err = run(ttf, *size, *outPath)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "fontgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// fontDirs are searched after -fontdir for a font given by file name only
+var fontDirs = []string{
+	"/usr/share/fonts/truetype/dejavu",
+	"/usr/share/fonts/dejavu",
+	"/usr/share/fonts/TTF",
+	"/usr/local/share/fonts",
+	"/opt/homebrew/share/fonts",
+	"/Library/Fonts",
+}
+
+// findFont resolves name to a font file. A name with a directory is used as
+// is; a bare file name is looked up in dir and then in fontDirs.
+func findFont(name, dir string) (string, error) {
+	if filepath.Base(name) != name {
+		return name, nil
+	}
+	dirs := fontDirs
+	if dir != "" {
+		dirs = append([]string{dir}, dirs...)
+	}
+	for _, d := range dirs {
+		path := filepath.Join(d, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("%s not found in %s; install the font or set FONTGEN_DIR (or -fontdir) to the directory containing it", name, strings.Join(dirs, ", "))
+}
+
+func run(ttfPath string, size float64, outPath string) error {
+	data, err := os.ReadFile(ttfPath)
+	if err != nil {
+		return err
+	}
+	f, err := parseFont(data)
+	if err != nil {
+		return err
+	}
+
+	scale := size / float64(f.unitsPerEm)
+	advance, err := f.advance(f.glyphIndex('M'))
+	if err != nil {
+		return err
+	}
+	cellW := int(math.Round(float64(advance) * scale))
+	ascent := float64(f.ascent) * scale
+	cellH := int(math.Ceil(ascent + float64(-f.descent)*scale))
+
+	atlas := image.NewGray(image.Rect(0, 0, cellW*columns, cellH*rows))
+	for i := 0; i < columns*rows; i++ {
+		r := rune(firstChar + i)
+		contours, err := f.glyph(f.glyphIndex(r), 0)
+		if err != nil {
+			return fmt.Errorf("glyph %q: %w", r, err)
+		}
+
+		// Convert font units (y up) to pixel space (y down) inside the cell
+		var edges []edge
+		for _, contour := range contours {
+			pts := flatten(contour)
+			for j := range pts {
+				a, b := pts[j], pts[(j+1)%len(pts)]
+				edges = append(edges, edge{
+					x0: a.x * scale, y0: ascent - a.y*scale,
+					x1: b.x * scale, y1: ascent - b.y*scale,
+				})
+			}
+		}
+
+		ox := (i % columns) * cellW
+		oy := (i / columns) * cellH
+		fill(atlas, edges, ox, oy, cellW, cellH)
+	}
+
+	out, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	return png.Encode(out, atlas)
+}
+
+// =============================================================================
+// Rasterizer
+// =============================================================================
+
+type point struct {
+	x, y    float64
+	onCurve bool
+}
+
+type edge struct {
+	x0, y0, x1, y1 float64
+}
+
+// flatten converts a TrueType quadratic contour into a closed polyline
+func flatten(contour []point) []point {
+	n := len(contour)
+	if n == 0 {
+		return nil
+	}
+
+	// Find an on-curve starting point, synthesizing one if needed
+	start := -1
+	for i, p := range contour {
+		if p.onCurve {
+			start = i
+			break
+		}
+	}
+	var first point
+	if start < 0 {
+		first = midpoint(contour[0], contour[1%n])
+		start = 0
+	} else {
+		first = contour[start]
+		start++
+	}
+
+	out := []point{first}
+	prev := first
+	var ctrl *point
+	for k := 0; k < n; k++ {
+		p := contour[(start+k)%n]
+		if p.onCurve {
+			if ctrl != nil {
+				out = append(out, quad(prev, *ctrl, p)...)
+				ctrl = nil
+			} else {
+				out = append(out, p)
+			}
+			prev = p
+			continue
+		}
+		if ctrl != nil {
+			mid := midpoint(*ctrl, p)
+			out = append(out, quad(prev, *ctrl, mid)...)
+			prev = mid
+		}
+		c := p
+		ctrl = &c
+	}
+	if ctrl != nil {
+		out = append(out, quad(prev, *ctrl, first)...)
+	}
+	return out
+}
+
+func midpoint(a, b point) point {
+	return point{x: (a.x + b.x) / 2, y: (a.y + b.y) / 2, onCurve: true}
+}
+
+// quad subdivides a quadratic bezier into line segments, excluding p0
+func quad(p0, c, p1 point) []point {
+	const steps = 8
+	out := make([]point, 0, steps)
+	for i := 1; i <= steps; i++ {
+		t := float64(i) / steps
+		u := 1 - t
+		out = append(out, point{
+			x:       u*u*p0.x + 2*u*t*c.x + t*t*p1.x,
+			y:       u*u*p0.y + 2*u*t*c.y + t*t*p1.y,
+			onCurve: true,
+		})
+	}
+	return out
+}
+
+type crossing struct {
+	x       float64
+	winding int
+}
+
+// fill renders edges into a cell using non-zero winding and box-filtered
+// supersampling.
+func fill(dst *image.Gray, edges []edge, ox, oy, w, h int) {
+	coverage := make([]int, w*h)
+	for sy := 0; sy < h*subY; sy++ {
+		y := (float64(sy) + 0.5) / subY
+
+		var xs []crossing
+		for _, e := range edges {
+			if e.y0 == e.y1 {
+				continue
+			}
+			dir := 1
+			y0, y1, x0, x1 := e.y0, e.y1, e.x0, e.x1
+			if y0 > y1 {
+				dir = -1
+				y0, y1, x0, x1 = y1, y0, x1, x0
+			}
+			if y < y0 || y >= y1 {
+				continue
+			}
+			xs = append(xs, crossing{x: x0 + (y-y0)/(y1-y0)*(x1-x0), winding: dir})
+		}
+		sort.Slice(xs, func(i, j int) bool { return xs[i].x < xs[j].x })
+
+		winding := 0
+		for i := 0; i+1 < len(xs); i++ {
+			winding += xs[i].winding
+			if winding == 0 {
+				continue
+			}
+			from := int(math.Round(xs[i].x * subX))
+			to := int(math.Round(xs[i+1].x * subX))
+			for sx := max(from, 0); sx < min(to, w*subX); sx++ {
+				coverage[(sy/subY)*w+sx/subX]++
+			}
+		}
+	}
+
+	for py := 0; py < h; py++ {
+		for px := 0; px < w; px++ {
+			v := coverage[py*w+px] * 255 / (subX * subY)
+			dst.Pix[(oy+py)*dst.Stride+ox+px] = uint8(min(v, 255))
+		}
+	}
+}
+
+// =============================================================================
+// TrueType parsing (just enough for simple monospace fonts)
+// =============================================================================
+
+type font struct {
+	data       []byte
+	tables     map[string][]byte
+	unitsPerEm int
+	ascent     int
+	descent    int
+	longLoca   bool
+	numHMetric int
+	cmap       []byte
+}
+
+func parseFont(data []byte) (*font, error) {
+	if len(data) < 12 {
+		return nil, errors.New("font file too short")
+	}
+	f := &font{data: data, tables: make(map[string][]byte)}
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	for i := 0; i < numTables; i++ {
+		rec := data[12+16*i:]
+		tag := string(rec[:4])
+		off := binary.BigEndian.Uint32(rec[8:])
+		length := binary.BigEndian.Uint32(rec[12:])
+		if int(off+length) > len(data) {
+			return nil, fmt.Errorf("table %s out of bounds", tag)
+		}
+		f.tables[tag] = data[off : off+length]
+	}
+	for _, tag := range []string{"head", "hhea", "hmtx", "cmap", "loca", "glyf"} {
+		if _, ok := f.tables[tag]; !ok {
+			return nil, fmt.Errorf("missing %s table", tag)
+		}
+	}
+
+	head := f.tables["head"]
+	f.unitsPerEm = int(binary.BigEndian.Uint16(head[18:]))
+	f.longLoca = binary.BigEndian.Uint16(head[50:]) == 1
+
+	hhea := f.tables["hhea"]
+	f.ascent = int(int16(binary.BigEndian.Uint16(hhea[4:])))
+	f.descent = int(int16(binary.BigEndian.Uint16(hhea[6:])))
+	f.numHMetric = int(binary.BigEndian.Uint16(hhea[34:]))
+
+	cmap := f.tables["cmap"]
+	numSub := int(binary.BigEndian.Uint16(cmap[2:]))
+	for i := 0; i < numSub; i++ {
+		rec := cmap[4+8*i:]
+		platform := binary.BigEndian.Uint16(rec)
+		encoding := binary.BigEndian.Uint16(rec[2:])
+		sub := cmap[binary.BigEndian.Uint32(rec[4:]):]
+		if binary.BigEndian.Uint16(sub) == 4 && (platform == 0 || (platform == 3 && encoding == 1)) {
+			f.cmap = sub
+			break
+		}
+	}
+	if f.cmap == nil {
+		return nil, errors.New("no format 4 unicode cmap")
+	}
+	return f, nil
+}
+
+// glyphIndex maps a BMP rune to a glyph index using cmap format 4
+func (f *font) glyphIndex(r rune) int {
+	c := f.cmap
+	segX2 := int(binary.BigEndian.Uint16(c[6:]))
+	ends := c[14:]
+	starts := c[16+segX2:]
+	deltas := c[16+2*segX2:]
+	offsets := c[16+3*segX2:]
+	for i := 0; i < segX2; i += 2 {
+		end := rune(binary.BigEndian.Uint16(ends[i:]))
+		start := rune(binary.BigEndian.Uint16(starts[i:]))
+		if r > end || r < start {
+			continue
+		}
+		delta := int(int16(binary.BigEndian.Uint16(deltas[i:])))
+		rangeOff := int(binary.BigEndian.Uint16(offsets[i:]))
+		if rangeOff == 0 {
+			return (int(r) + delta) & 0xffff
+		}
+		idx := i + rangeOff + 2*int(r-start)
+		g := int(binary.BigEndian.Uint16(offsets[idx:]))
+		if g == 0 {
+			return 0
+		}
+		return (g + delta) & 0xffff
+	}
+	return 0
+}
+
+func (f *font) advance(g int) (int, error) {
+	hmtx := f.tables["hmtx"]
+	if g >= f.numHMetric {
+		g = f.numHMetric - 1
+	}
+	if 4*g+2 > len(hmtx) {
+		return 0, errors.New("hmtx out of bounds")
+	}
+	return int(binary.BigEndian.Uint16(hmtx[4*g:])), nil
+}
+
+func (f *font) glyphData(g int) []byte {
+	loca := f.tables["loca"]
+	var start, end int
+	if f.longLoca {
+		start = int(binary.BigEndian.Uint32(loca[4*g:]))
+		end = int(binary.BigEndian.Uint32(loca[4*g+4:]))
+	} else {
+		start = 2 * int(binary.BigEndian.Uint16(loca[2*g:]))
+		end = 2 * int(binary.BigEndian.Uint16(loca[2*g+2:]))
+	}
+	return f.tables["glyf"][start:end]
+}
+
+// glyph returns the contours of a glyph in font units
+func (f *font) glyph(g int, depth int) ([][]point, error) {
+	if depth > 8 {
+		return nil, errors.New("composite glyph nesting too deep")
+	}
+	data := f.glyphData(g)
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	numContours := int(int16(binary.BigEndian.Uint16(data)))
+	if numContours < 0 {
+		return f.compositeGlyph(data[10:], depth)
+	}
+
+	endPts := make([]int, numContours)
+	for i := range endPts {
+		endPts[i] = int(binary.BigEndian.Uint16(data[10+2*i:]))
+	}
+	numPoints := 0
+	if numContours > 0 {
+		numPoints = endPts[numContours-1] + 1
+	}
+	p := 10 + 2*numContours
+	p += 2 + int(binary.BigEndian.Uint16(data[p:])) // skip instructions
+
+	flags := make([]byte, 0, numPoints)
+	for len(flags) < numPoints {
+		flag := data[p]
+		p++
+		flags = append(flags, flag)
+		if flag&0x08 != 0 {
+			repeat := int(data[p])
+			p++
+			for ; repeat > 0; repeat-- {
+				flags = append(flags, flag)
+			}
+		}
+	}
+
+	readCoords := func(short, same byte) []int {
+		coords := make([]int, numPoints)
+		v := 0
+		for i, flag := range flags {
+			switch {
+			case flag&short != 0:
+				d := int(data[p])
+				p++
+				if flag&same == 0 {
+					d = -d
+				}
+				v += d
+			case flag&same == 0:
+				v += int(int16(binary.BigEndian.Uint16(data[p:])))
+				p += 2
+			}
+			coords[i] = v
+		}
+		return coords
+	}
+	xs := readCoords(0x02, 0x10)
+	ys := readCoords(0x04, 0x20)
+
+	contours := make([][]point, 0, numContours)
+	start := 0
+	for _, end := range endPts {
+		contour := make([]point, 0, end-start+1)
+		for i := start; i <= end; i++ {
+			contour = append(contour, point{x: float64(xs[i]), y: float64(ys[i]), onCurve: flags[i]&0x01 != 0})
+		}
+		contours = append(contours, contour)
+		start = end + 1
+	}
+	return contours, nil
+}
+
+func (f *font) compositeGlyph(data []byte, depth int) ([][]point, error) {
+	var contours [][]point
+	for {
+		flags := binary.BigEndian.Uint16(data)
+		g := int(binary.BigEndian.Uint16(data[2:]))
+		data = data[4:]
+
+		var dx, dy float64
+		if flags&0x0001 != 0 {
+			dx = float64(int16(binary.BigEndian.Uint16(data)))
+			dy = float64(int16(binary.BigEndian.Uint16(data[2:])))
+			data = data[4:]
+		} else {
+			dx = float64(int8(data[0]))
+			dy = float64(int8(data[1]))
+			data = data[2:]
+		}
+		if flags&0x0002 == 0 {
+			return nil, errors.New("point-matched composite glyphs are not supported")
+		}
+
+		sx, sy := 1.0, 1.0
+		f2dot14 := func(b []byte) float64 { return float64(int16(binary.BigEndian.Uint16(b))) / 16384 }
+		switch {
+		case flags&0x0008 != 0:
+			sx = f2dot14(data)
+			sy = sx
+			data = data[2:]
+		case flags&0x0040 != 0:
+			sx, sy = f2dot14(data), f2dot14(data[2:])
+			data = data[4:]
+		case flags&0x0080 != 0:
+			sx, sy = f2dot14(data), f2dot14(data[6:])
+			data = data[8:]
+		}
+
+		sub, err := f.glyph(g, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		for _, contour := range sub {
+			moved := make([]point, len(contour))
+			for i, pt := range contour {
+				moved[i] = point{x: pt.x*sx + dx, y: pt.y*sy + dy, onCurve: pt.onCurve}
+			}
+			contours = append(contours, moved)
+		}
+
+		if flags&0x0020 == 0 {
+			return contours, nil
+		}
+	}
+}
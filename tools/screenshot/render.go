@@ -0,0 +1,477 @@
+// Package screenshot renders deterministic PNG previews of each theme
+// variant: a representative Zed window with a project panel, tabs, a
+// syntax-highlighted editor, a terminal pane and a status bar. Frosted
+// variants are drawn over a blurred sample wallpaper.
+package screenshot
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/cvd"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/highlight"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+// Options controls the screenshot layout
+type Options struct {
+	Width  int
+	Height int
+
+	// FileName and Source are shown in the editor
+	FileName string
+	Source   []byte
+
+	// ProjectFiles are listed in the project panel
+	ProjectFiles []string
+
+	// Deficiencies additionally writes <slug>-<deficiency>.png for each
+	// simulated color vision deficiency
+	Deficiencies []cvd.Deficiency
+}
+
+// DefaultOptions returns the layout used for the README screenshots
+func DefaultOptions() Options {
+	return Options{
+		Width:  1280,
+		Height: 800,
+	}
+}
+
+// Layout constants in pixels
+const (
+	titleBarHeight  = 34
+	tabBarHeight    = 34
+	toolbarHeight   = 28
+	statusBarHeight = 28
+	panelWidth      = 240
+	terminalHeight  = 210
+	frostedMargin   = 48
+	blurRadius      = 18
+	gutterPadding   = 16
+	wrapColumn      = 100
+	activeLine      = 9
+)
+
+// Render draws a single variant and returns the image
+func Render(variant palette.ThemeVariant, opts Options) *image.NRGBA {
+	style := palette.GenerateThemeStyle(variant.Name, variant.Appearance, variant.Palette)
+	s := style.Style
+
+	w, h := opts.Width, opts.Height
+	cv := newCanvas(w, h)
+
+	win := image.Rect(0, 0, w, h)
+	if s.BackgroundAppearance == "blurred" {
+		desktop := wallpaper(w, h)
+		copy(cv.img.Pix, desktop.Pix)
+		win = win.Inset(frostedMargin)
+
+		// Everything behind the window is blurred by the compositor
+		blurred := blur(desktop, blurRadius)
+		for y := win.Min.Y; y < win.Max.Y; y++ {
+			i := cv.img.PixOffset(win.Min.X, y)
+			j := i + win.Dx()*4
+			copy(cv.img.Pix[i:j], blurred.Pix[i:j])
+		}
+	}
+
+	r := &renderer{cv: cv, s: s, opts: opts, variant: variant}
+	r.window(win)
+	return cv.img
+}
+
+// WriteAll renders every variant into dir as <slug>.png
+func WriteAll(variants []palette.ThemeVariant, dir string, opts Options) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, v := range variants {
+		img := Render(v, opts)
+		path := filepath.Join(dir, v.Slug()+".png")
+		if err := writePNG(path, img); err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+		for _, d := range opts.Deficiencies {
+			path := filepath.Join(dir, v.Slug()+"-"+string(d)+".png")
+			if err := writePNG(path, cvd.SimulateImage(img, d)); err != nil {
+				return fmt.Errorf("writing %s: %w", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+type renderer struct {
+	cv      *canvas
+	s       *palette.ThemeStyle
+	opts    Options
+	variant palette.ThemeVariant
+}
+
+// c parses a theme color; empty (omitted) colors are transparent
+func c(hex string) colormath.Color {
+	if hex == "" {
+		return colormath.Color{}
+	}
+	return colormath.MustParseHex(hex)
+}
+
+func (r *renderer) window(win image.Rectangle) {
+	s := r.s
+	cv := r.cv
+	cv.fill(win, c(s.Background))
+
+	title := image.Rect(win.Min.X, win.Min.Y, win.Max.X, win.Min.Y+titleBarHeight)
+	status := image.Rect(win.Min.X, win.Max.Y-statusBarHeight, win.Max.X, win.Max.Y)
+	panel := image.Rect(win.Min.X, title.Max.Y, win.Min.X+panelWidth, status.Min.Y)
+	main := image.Rect(panel.Max.X, title.Max.Y, win.Max.X, status.Min.Y)
+	terminal := image.Rect(main.Min.X, main.Max.Y-terminalHeight, main.Max.X, main.Max.Y)
+	editor := image.Rect(main.Min.X, main.Min.Y, main.Max.X, terminal.Min.Y)
+
+	r.titleBar(title)
+	r.projectPanel(panel)
+	r.editorPane(editor)
+	r.terminal(terminal)
+	r.statusBar(status)
+
+	if r.s.BackgroundAppearance == "blurred" {
+		border := c(s.Border)
+		cv.hline(win.Min.X, win.Max.X, win.Min.Y, border)
+		cv.hline(win.Min.X, win.Max.X, win.Max.Y-1, border)
+		cv.vline(win.Min.X, win.Min.Y, win.Max.Y, border)
+		cv.vline(win.Max.X-1, win.Min.Y, win.Max.Y, border)
+	}
+}
+
+// textY returns the y offset that vertically centers a text line in a bar
+func textY(bar image.Rectangle) int {
+	_, ch := cellSize()
+	return bar.Min.Y + (bar.Dy()-ch)/2
+}
+
+func (r *renderer) titleBar(bar image.Rectangle) {
+	s, cv := r.s, r.cv
+	cv.fill(bar, c(s.TitleBarBackground))
+	cv.hline(bar.Min.X, bar.Max.X, bar.Max.Y-1, c(s.Border))
+
+	// Window controls
+	cy := bar.Min.Y + bar.Dy()/2
+	for i, col := range []string{s.Error, s.Warning, s.Success} {
+		cv.disc(bar.Min.X+18+i*20, cy, 6, c(col))
+	}
+
+	y := textY(bar)
+	x := cv.text(bar.Min.X+84, y, "zed-theme-tron-legacy", c(s.Text), textStyle{bold: true})
+	x = cv.text(x+12, y, "main", c(s.TextMuted), textStyle{})
+	cw, _ := cellSize()
+	cv.text(bar.Max.X-cw*len(r.variant.Name)-16, y, r.variant.Name, c(s.TextMuted), textStyle{})
+}
+
+func (r *renderer) projectPanel(panel image.Rectangle) {
+	s, cv := r.s, r.cv
+	cv.fill(panel, c(s.PanelBackground))
+	cv.vline(panel.Max.X-1, panel.Min.Y, panel.Max.Y, c(s.Border))
+
+	_, ch := cellSize()
+	rowH := ch + 8
+	y := panel.Min.Y + 8
+	cv.text(panel.Min.X+12, y+4, "EXAMPLES", c(s.TextMuted), textStyle{bold: true})
+	y += rowH
+
+	statuses := []struct{ fg string }{
+		{s.Modified}, {s.Text}, {s.Created}, {s.Text}, {s.Deleted},
+		{s.Text}, {s.Ignored}, {s.Conflict}, {s.Text}, {s.Renamed},
+	}
+
+	for i, name := range r.opts.ProjectFiles {
+		row := image.Rect(panel.Min.X, y, panel.Max.X-1, y+rowH)
+		if row.Max.Y > panel.Max.Y {
+			break
+		}
+		fg := c(s.Text)
+		if i < len(statuses) {
+			fg = c(statuses[i].fg)
+		}
+		if name == r.opts.FileName {
+			cv.fill(row, c(s.ElementSelected))
+			cv.vline(row.Min.X, row.Min.Y, row.Max.Y, c(s.PanelFocusedBorder))
+			cv.vline(row.Min.X+1, row.Min.Y, row.Max.Y, c(s.PanelFocusedBorder))
+		}
+		cv.vline(panel.Min.X+18, row.Min.Y, row.Max.Y, c(s.PanelIndentGuide))
+		cv.text(panel.Min.X+28, y+4, name, fg, textStyle{})
+		y += rowH
+	}
+}
+
+func (r *renderer) editorPane(pane image.Rectangle) {
+	s, cv := r.s, r.cv
+	cw, ch := cellSize()
+
+	// Tabs
+	tabs := image.Rect(pane.Min.X, pane.Min.Y, pane.Max.X, pane.Min.Y+tabBarHeight)
+	cv.fill(tabs, c(s.TabBarBackground))
+	x := tabs.Min.X
+	names := []string{r.opts.FileName}
+	for _, name := range r.opts.ProjectFiles {
+		if name != r.opts.FileName && len(names) < 3 {
+			names = append(names, name)
+		}
+	}
+	for i, name := range names {
+		tab := image.Rect(x, tabs.Min.Y, x+len(name)*cw+40, tabs.Max.Y)
+		if i == 0 {
+			cv.fill(tab, c(s.TabActiveBackground))
+			cv.text(tab.Min.X+20, textY(tab), name, c(s.Text), textStyle{})
+		} else {
+			cv.fill(tab, c(s.TabInactiveBackground))
+			cv.hline(tab.Min.X, tab.Max.X, tab.Max.Y-1, c(s.Border))
+			cv.text(tab.Min.X+20, textY(tab), name, c(s.TextMuted), textStyle{})
+		}
+		cv.vline(tab.Max.X-1, tab.Min.Y, tab.Max.Y, c(s.Border))
+		x = tab.Max.X
+	}
+	cv.hline(x, tabs.Max.X, tabs.Max.Y-1, c(s.Border))
+
+	// Breadcrumb toolbar
+	toolbar := image.Rect(pane.Min.X, tabs.Max.Y, pane.Max.X, tabs.Max.Y+toolbarHeight)
+	cv.fill(toolbar, c(s.ToolbarBackground))
+	cv.text(toolbar.Min.X+12, textY(toolbar), "examples/"+r.opts.FileName, c(s.TextMuted), textStyle{})
+
+	// Editor
+	body := image.Rect(pane.Min.X, toolbar.Max.Y, pane.Max.X, pane.Max.Y)
+	cv.fill(body, c(s.EditorBackground))
+
+	lines := highlight.ForFile(r.opts.FileName).Lines(string(r.opts.Source))
+	lineH := ch + 4
+	gutterW := 4*cw + 2*gutterPadding
+	gutter := image.Rect(body.Min.X, body.Min.Y, body.Min.X+gutterW, body.Max.Y)
+	cv.fill(gutter, c(s.EditorGutterBackground))
+	codeX := gutter.Max.X + 8
+
+	cv.vline(codeX+wrapColumn*cw, body.Min.Y, body.Max.Y, c(s.EditorWrapGuide))
+
+	for i, tokens := range lines {
+		y := body.Min.Y + 6 + i*lineH
+		if y+lineH > body.Max.Y {
+			break
+		}
+		lineNo := i + 1
+		row := image.Rect(body.Min.X, y, body.Max.X, y+lineH)
+		ty := y + 2
+
+		if lineNo == activeLine {
+			cv.fill(image.Rect(gutter.Max.X, row.Min.Y, row.Max.X, row.Max.Y), c(s.EditorActiveLineBackground))
+		}
+
+		// Git gutter markers
+		switch lineNo {
+		case 4, 5:
+			cv.fill(image.Rect(gutter.Max.X-4, row.Min.Y, gutter.Max.X-1, row.Max.Y), c(s.VersionControlAdded))
+		case 10, 11:
+			cv.fill(image.Rect(gutter.Max.X-4, row.Min.Y, gutter.Max.X-1, row.Max.Y), c(s.VersionControlModified))
+		case 15:
+			cv.fill(image.Rect(gutter.Max.X-6, row.Min.Y-3, gutter.Max.X-1, row.Min.Y+3), c(s.VersionControlDeleted))
+		}
+
+		numColor := c(s.EditorLineNumber)
+		if lineNo == activeLine {
+			numColor = c(s.EditorActiveLineNumber)
+		}
+		num := fmt.Sprintf("%4d", lineNo)
+		cv.text(gutter.Max.X-gutterPadding-len(num)*cw, ty, num, numColor, textStyle{})
+
+		// Indent guides
+		indent := len(plainText(tokens)) - len(strings.TrimLeft(plainText(tokens), " "))
+		for col := 4; col <= indent && strings.TrimSpace(plainText(tokens)) != ""; col += 4 {
+			guide := c(s.EditorIndentGuide)
+			if lineNo >= activeLine-3 && lineNo <= activeLine+3 {
+				guide = c(s.EditorIndentGuideActive)
+			}
+			cv.vline(codeX+(col-4)*cw, row.Min.Y, row.Max.Y, guide)
+		}
+
+		r.decorations(tokens, lineNo, codeX, row)
+
+		x := codeX
+		for _, tok := range tokens {
+			fg, ts := r.tokenStyle(tok.Kind)
+			x = cv.text(x, ty, tok.Text, fg, ts)
+		}
+
+		if lineNo == activeLine {
+			cursor := r.s.Players[0]
+			cx := codeX + 14*cw
+			cv.fill(image.Rect(cx, row.Min.Y+1, cx+2, row.Max.Y-1), c(cursor.Cursor))
+		}
+	}
+
+	// Scrollbar
+	track := image.Rect(body.Max.X-14, body.Min.Y, body.Max.X, body.Max.Y)
+	cv.fill(track, c(s.ScrollbarTrackBackground))
+	cv.vline(track.Min.X, track.Min.Y, track.Max.Y, c(s.ScrollbarTrackBorder))
+	thumb := image.Rect(track.Min.X+3, track.Min.Y+4, track.Max.X-3, track.Min.Y+4+track.Dy()/4)
+	cv.fill(thumb, c(s.ScrollbarThumbBackground))
+}
+
+// decorations draws selections, search matches, document highlights and
+// diagnostics under and around the text of a line.
+func (r *renderer) decorations(tokens []highlight.Token, lineNo, codeX int, row image.Rectangle) {
+	s, cv := r.s, r.cv
+	cw, _ := cellSize()
+	text := plainText(tokens)
+	span := func(start, end int) image.Rectangle {
+		return image.Rect(codeX+start*cw, row.Min.Y, codeX+end*cw, row.Max.Y)
+	}
+
+	// Search matches for "string"
+	for i := 0; ; {
+		j := strings.Index(strings.ToLower(text[i:]), "string")
+		if j < 0 {
+			break
+		}
+		cv.fill(span(i+j, i+j+len("string")), c(s.SearchMatchBackground))
+		i += j + len("string")
+	}
+
+	switch lineNo {
+	case activeLine + 1:
+		// A selection covering the rest of the line
+		start := len(text) - len(strings.TrimLeft(text, " "))
+		cv.fill(span(start, len(text)), c(s.EditorSelectionBackground))
+	case activeLine + 2, activeLine + 3:
+		// Document highlights on the first identifier
+		start := len(text) - len(strings.TrimLeft(text, " "))
+		end := start
+		for end < len(text) && text[end] != ' ' && text[end] != '(' {
+			end++
+		}
+		bg := s.EditorDocumentHighlightReadBackground
+		if lineNo == activeLine+2 {
+			bg = s.EditorDocumentHighlightWriteBackground
+		}
+		cv.fill(span(start, end), c(bg))
+	}
+
+	switch lineNo {
+	case 12:
+		cv.squiggle(codeX, codeX+len(strings.TrimRight(text, " "))*cw, row.Max.Y-4, c(s.Error))
+	case 13:
+		cv.squiggle(codeX, codeX+len(strings.TrimRight(text, " "))*cw, row.Max.Y-4, c(s.Warning))
+	}
+}
+
+// tokenStyle resolves a highlight name to a color and font style
+func (r *renderer) tokenStyle(kind string) (colormath.Color, textStyle) {
+	st, ok := r.s.Syntax.Resolve(kind)
+	if !ok {
+		return c(r.s.EditorForeground), textStyle{}
+	}
+	return c(st.Color), textStyle{
+		bold:   st.FontWeight != nil && *st.FontWeight >= 600,
+		italic: st.FontStyle != nil && *st.FontStyle == "italic",
+	}
+}
+
+func (r *renderer) terminal(pane image.Rectangle) {
+	s, cv := r.s, r.cv
+	cw, ch := cellSize()
+	cv.fill(pane, c(s.TerminalBackground))
+	cv.hline(pane.Min.X, pane.Max.X, pane.Min.Y, c(s.PaneGroupBorder))
+
+	header := image.Rect(pane.Min.X, pane.Min.Y+1, pane.Max.X, pane.Min.Y+tabBarHeight)
+	cv.fill(header, c(s.TabBarBackground))
+	cv.text(header.Min.X+20, textY(header), "Terminal", c(s.Text), textStyle{})
+	cv.hline(header.Min.X, header.Max.X, header.Max.Y-1, c(s.Border))
+
+	type seg struct {
+		text string
+		fg   string
+		bold bool
+	}
+	prompt := []seg{{"~/zed-theme-tron-legacy", s.TerminalAnsiBlue, true}, {" on ", s.TerminalForeground, false}, {"main", s.TerminalAnsiMagenta, true}, {" $ ", s.TerminalForeground, false}}
+	lines := [][]seg{
+		append(prompt, seg{"git status --short", s.TerminalForeground, false}),
+		{{" M ", s.TerminalAnsiRed, false}, {"tools/dark/colors.css", s.TerminalForeground, false}},
+		{{"A  ", s.TerminalAnsiGreen, false}, {"tools/screenshot/render.go", s.TerminalForeground, false}},
+		{{"?? ", s.TerminalAnsiBrightBlack, false}, {"screenshots/generated/", s.TerminalForeground, false}},
+		append(prompt, seg{"ls", s.TerminalForeground, false}),
+		{{"examples/  ", s.TerminalAnsiBlue, true}, {"themes/  ", s.TerminalAnsiBlue, true}, {"tools/  ", s.TerminalAnsiBlue, true}, {"install.sh  ", s.TerminalAnsiGreen, true}, {"latest -> v1  ", s.TerminalAnsiCyan, false}, {"README.md", s.TerminalForeground, false}},
+	}
+
+	lineH := ch + 3
+	y := header.Max.Y + 8
+	for _, line := range lines {
+		x := pane.Min.X + 16
+		for _, sg := range line {
+			x = cv.text(x, y, sg.text, c(sg.fg), textStyle{bold: sg.bold})
+		}
+		y += lineH
+	}
+
+	// ANSI palette swatches: normal, bright and dim rows
+	swatches := [][]string{
+		{s.TerminalAnsiBlack, s.TerminalAnsiRed, s.TerminalAnsiGreen, s.TerminalAnsiYellow, s.TerminalAnsiBlue, s.TerminalAnsiMagenta, s.TerminalAnsiCyan, s.TerminalAnsiWhite},
+		{s.TerminalAnsiBrightBlack, s.TerminalAnsiBrightRed, s.TerminalAnsiBrightGreen, s.TerminalAnsiBrightYellow, s.TerminalAnsiBrightBlue, s.TerminalAnsiBrightMagenta, s.TerminalAnsiBrightCyan, s.TerminalAnsiBrightWhite},
+		{s.TerminalAnsiDimBlack, s.TerminalAnsiDimRed, s.TerminalAnsiDimGreen, s.TerminalAnsiDimYellow, s.TerminalAnsiDimBlue, s.TerminalAnsiDimMagenta, s.TerminalAnsiDimCyan, s.TerminalAnsiDimWhite},
+	}
+	sx := pane.Max.X - 8*5*cw - 32
+	for row, colors := range swatches {
+		for i, col := range colors {
+			sw := image.Rect(sx+i*5*cw, header.Max.Y+8+row*(lineH+4), sx+(i+1)*5*cw-4, header.Max.Y+8+row*(lineH+4)+lineH)
+			cv.fill(sw, c(col))
+		}
+	}
+
+	// Block cursor at the final prompt
+	cursor := image.Rect(pane.Min.X+16, y, pane.Min.X+16+cw, y+ch)
+	cv.text(cv.text(pane.Min.X+16, y, "~/zed-theme-tron-legacy", c(s.TerminalAnsiBlue), textStyle{bold: true}), y, " $ ", c(s.TerminalForeground), textStyle{})
+	cursor = cursor.Add(image.Pt((len("~/zed-theme-tron-legacy")+3)*cw, 0))
+	cv.fill(cursor, c(s.Players[0].Cursor))
+}
+
+func (r *renderer) statusBar(bar image.Rectangle) {
+	s, cv := r.s, r.cv
+	cw, _ := cellSize()
+	cv.fill(bar, c(s.StatusBarBackground))
+	cv.hline(bar.Min.X, bar.Max.X, bar.Min.Y, c(s.Border))
+
+	y := textY(bar)
+	cy := bar.Min.Y + bar.Dy()/2
+	x := bar.Min.X + 16
+	cv.disc(x+4, cy, 5, c(s.Error))
+	x = cv.text(x+14, y, "1", c(s.Text), textStyle{})
+	cv.disc(x+14, cy, 5, c(s.Warning))
+	x = cv.text(x+24, y, "2", c(s.Text), textStyle{})
+	cv.disc(x+14, cy, 5, c(s.Info))
+	cv.text(x+24, y, "3", c(s.Text), textStyle{})
+
+	lang := highlight.ForFile(r.opts.FileName).Name
+	right := fmt.Sprintf("Ln %d, Col 15   %s   UTF-8", activeLine, lang)
+	cv.text(bar.Max.X-len(right)*cw-16, y, right, c(s.TextMuted), textStyle{})
+}
+
+func plainText(tokens []highlight.Token) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteString(t.Text)
+	}
+	return b.String()
+}
@@ -0,0 +1,51 @@
+package screenshot
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/dark"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/light"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+func TestRenderIsDeterministic(t *testing.T) {
+	opts := Options{
+		Width:        1280,
+		Height:       800,
+		FileName:     "example.js",
+		Source:       []byte("// comment\nconst answer = \"forty two\";\nfunction f() { return 42; }\n"),
+		ProjectFiles: []string{"example.js", "example.py"},
+	}
+
+	variants := []palette.ThemeVariant{
+		{Name: "Tron Legacy", Appearance: "dark", Palette: dark.GetPalette()},
+		{Name: "Tron Legacy Light Frosted", Appearance: "light", Palette: light.GetFrostedPalette()},
+	}
+
+	for _, v := range variants {
+		var first, second bytes.Buffer
+		if err := png.Encode(&first, Render(v, opts)); err != nil {
+			t.Fatalf("%s: encoding PNG: %v", v.Name, err)
+		}
+		if err := png.Encode(&second, Render(v, opts)); err != nil {
+			t.Fatalf("%s: encoding PNG: %v", v.Name, err)
+		}
+		if !bytes.Equal(first.Bytes(), second.Bytes()) {
+			t.Errorf("%s: rendering the same variant twice produced different PNGs", v.Name)
+		}
+	}
+}
+
+func TestFrostedVariantShowsWallpaper(t *testing.T) {
+	opts := DefaultOptions()
+	opts.FileName = "example.js"
+	v := palette.ThemeVariant{Name: "Tron Legacy Frosted", Appearance: "dark", Palette: dark.GetFrostedPalette()}
+	img := Render(v, opts)
+
+	// The corner lies outside the window, so it must be the raw wallpaper
+	if got, want := img.NRGBAAt(0, 0), wallpaper(opts.Width, opts.Height).NRGBAAt(0, 0); got != want {
+		t.Errorf("corner pixel = %v, want wallpaper %v", got, want)
+	}
+}
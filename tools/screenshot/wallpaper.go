@@ -0,0 +1,116 @@
+package screenshot
+
+import (
+	"image"
+	"math"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+)
+
+// wallpaper paints a procedural Tron grid desktop. It is generated rather
+// than bundled so the frosted screenshots stay deterministic and small.
+func wallpaper(w, h int) *image.NRGBA {
+	cv := newCanvas(w, h)
+	horizon := h * 2 / 5
+
+	sky := [2]colormath.Color{
+		colormath.MustParseHex("#02060dff"),
+		colormath.MustParseHex("#0b2a3dff"),
+	}
+	floor := [2]colormath.Color{
+		colormath.MustParseHex("#041019ff"),
+		colormath.MustParseHex("#010305ff"),
+	}
+	lerp := func(a, b colormath.Color, t float64) colormath.Color {
+		return colormath.Color{
+			R: a.R + (b.R-a.R)*t,
+			G: a.G + (b.G-a.G)*t,
+			B: a.B + (b.B-a.B)*t,
+			A: 1,
+		}
+	}
+
+	for y := 0; y < h; y++ {
+		var c colormath.Color
+		if y < horizon {
+			c = lerp(sky[0], sky[1], float64(y)/float64(horizon))
+		} else {
+			c = lerp(floor[0], floor[1], float64(y-horizon)/float64(h-horizon))
+		}
+		cv.fill(image.Rect(0, y, w, y+1), c)
+	}
+
+	// Orange sun glow sitting on the horizon
+	glow := colormath.MustParseHex("#ff8a1eff")
+	cx, cy := w*2/3, horizon
+	for y := 0; y < horizon; y++ {
+		for x := 0; x < w; x++ {
+			d := math.Hypot(float64(x-cx), float64(y-cy)*1.6) / float64(h)
+			if a := 0.9 * math.Exp(-d*d*22); a > 0.01 {
+				cv.blend(x, y, glow.WithAlpha(a))
+			}
+		}
+	}
+
+	// Perspective grid on the floor
+	grid := colormath.MustParseHex("#6ee2ffff")
+	for i := 1; i < 14; i++ {
+		t := math.Pow(float64(i)/14, 2.2)
+		y := horizon + int(t*float64(h-horizon))
+		cv.hline(0, w, y, grid.WithAlpha(0.25+0.5*t))
+	}
+	vanish := float64(w) / 2
+	for i := -24; i <= 24; i++ {
+		bottom := vanish + float64(i)*float64(w)/10
+		for y := horizon; y < h; y++ {
+			t := float64(y-horizon) / float64(h-horizon)
+			x := int(vanish + (bottom-vanish)*t)
+			cv.blend(x, y, grid.WithAlpha(0.15+0.6*t))
+		}
+	}
+
+	// Horizon line
+	cv.hline(0, w, horizon, grid.WithAlpha(0.9))
+	return cv.img
+}
+
+// blur applies three passes of a separable box blur, which approximates
+// the gaussian blur compositors use for translucent windows.
+func blur(src *image.NRGBA, radius int) *image.NRGBA {
+	dst := image.NewNRGBA(src.Rect)
+	copy(dst.Pix, src.Pix)
+	tmp := make([]uint8, len(src.Pix))
+	for pass := 0; pass < 3; pass++ {
+		boxBlur(dst.Pix, tmp, dst.Rect.Dx(), dst.Rect.Dy(), radius, true)
+		boxBlur(tmp, dst.Pix, dst.Rect.Dx(), dst.Rect.Dy(), radius, false)
+	}
+	return dst
+}
+
+// boxBlur blurs src into dst along one axis with edge clamping
+func boxBlur(src, dst []uint8, w, h, radius int, horizontal bool) {
+	lines, length := h, w
+	if !horizontal {
+		lines, length = w, h
+	}
+	offset := func(line, i int) int {
+		i = max(0, min(length-1, i))
+		if horizontal {
+			return (line*w + i) * 4
+		}
+		return (i*w + line) * 4
+	}
+	window := 2*radius + 1
+	for line := 0; line < lines; line++ {
+		for ch := 0; ch < 4; ch++ {
+			sum := 0
+			for i := -radius; i <= radius; i++ {
+				sum += int(src[offset(line, i)+ch])
+			}
+			for i := 0; i < length; i++ {
+				dst[offset(line, i)+ch] = uint8(sum / window)
+				sum += int(src[offset(line, i+radius+1)+ch]) - int(src[offset(line, i-radius)+ch])
+			}
+		}
+	}
+}
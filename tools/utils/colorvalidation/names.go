@@ -0,0 +1,267 @@
+package colorvalidation
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+)
+
+// Mismatch is a color whose name or comment says something its value doesn't
+type Mismatch struct {
+	Line    int // 1-based line in the file checked, 0 when not tied to one
+	Name    string
+	Message string
+}
+
+func (m Mismatch) String() string {
+	if m.Line > 0 {
+		return fmt.Sprintf("line %d: --%s: %s", m.Line, m.Name, m.Message)
+	}
+	return fmt.Sprintf("--%s: %s", m.Name, m.Message)
+}
+
+var (
+	alphaName      = regexp.MustCompile(`^(.+?)Alpha(\d*)(.*)$`)
+	opacityPercent = regexp.MustCompile(`(\d+)%`)
+	stepName       = regexp.MustCompile(`^([a-z]+)(\d+)$`)
+)
+
+// alphaPercent returns a color's alpha as a whole percentage
+func alphaPercent(value string) (int, error) {
+	c, err := colormath.ParseHex(value)
+	if err != nil {
+		return 0, err
+	}
+	return int(math.Round(c.A * 100)), nil
+}
+
+// rgb returns a hex color's lowercase #rrggbb, expanding short forms like
+// #fff, or "" when value isn't a hex color
+func rgb(value string) string {
+	c, err := colormath.ParseHex(value)
+	if err != nil {
+		return ""
+	}
+	return c.Hex()[:7]
+}
+
+// statedOpacities returns the percentages in a comment that talks about
+// opacity, e.g. "Wrap guide with 25% opacity"
+func statedOpacities(comment string) []int {
+	if !strings.Contains(strings.ToLower(comment), "opacity") {
+		return nil
+	}
+	var pcts []int
+	for _, m := range opacityPercent.FindAllStringSubmatch(comment, -1) {
+		n, _ := strconv.Atoi(m[1])
+		pcts = append(pcts, n)
+	}
+	return pcts
+}
+
+// CheckColorNames compares what colors.css declarations claim with their
+// values:
+//
+//   - blue200Alpha40 must have the RGB of --blue200 and 40% alpha. The base
+//     check is skipped when the base variable isn't declared. Suggested names
+//     use the opaque variable the color really is.
+//   - a percentage in a comment mentioning opacity must match the alpha
+//   - a variable must not be declared twice
+func CheckColorNames(colorsCSS []byte) ([]Mismatch, error) {
+	colors, err := csscolors.LoadColors(colorsCSS)
+	if err != nil {
+		return nil, err
+	}
+
+	// The opaque variable each RGB belongs to, for suggesting names
+	opaque := map[string]string{}
+	list, err := csscolors.LoadColorList(colorsCSS)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range list {
+		if pct, err := alphaPercent(c.Value); err == nil && pct == 100 {
+			if _, ok := opaque[rgb(c.Value)]; !ok {
+				opaque[rgb(c.Value)] = c.Name
+			}
+		}
+	}
+
+	var found []Mismatch
+	seen := map[string]int{}
+	for _, d := range csscolors.LoadDeclarations(colorsCSS) {
+		report := func(format string, args ...any) {
+			found = append(found, Mismatch{Line: d.Line, Name: d.Name, Message: fmt.Sprintf(format, args...)})
+		}
+		if line, ok := seen[d.Name]; ok {
+			report("declared again (first on line %d)", line)
+			continue
+		}
+		seen[d.Name] = d.Line
+
+		pct, err := alphaPercent(d.Value)
+		if err != nil {
+			continue
+		}
+
+		if m := alphaName.FindStringSubmatch(d.Name); m != nil {
+			base, claimed := m[1], m[2]
+			suggest := base
+			if name, ok := opaque[rgb(d.Value)]; ok {
+				suggest = name
+			}
+			if baseValue, ok := colors[base]; ok && rgb(baseValue) != rgb(d.Value) {
+				report("named after --%s (%s) but its color is %s; call it %sAlpha%02d", base, baseValue, rgb(d.Value), suggest, pct)
+			} else if claimed == "" || m[3] != "" {
+				report("name doesn't say its opacity; call it %sAlpha%02d", suggest, pct)
+			} else if n, _ := strconv.Atoi(claimed); n != pct {
+				report("named %s%% but %s is %d%% opaque; call it %sAlpha%02d", claimed, d.Value, pct, suggest, pct)
+			}
+		}
+
+		for _, stated := range statedOpacities(d.Comment) {
+			if stated != pct {
+				report("comment says %d%% opacity but %s is %d%% opaque", stated, d.Value, pct)
+			}
+		}
+	}
+	return found, nil
+}
+
+// CheckOpacityComments checks Go comments like `colors.Gray900Frosted() //
+// 80% opacity` against the alpha of the variable used on that line
+func CheckOpacityComments(goSource []byte, colors csscolors.ColorMap) []Mismatch {
+	use := regexp.MustCompile(`colors\.(\w+)\(\)`)
+	names := make(map[string]string, len(colors))
+	for name := range colors {
+		names[csscolors.GoName(name)] = name
+	}
+	var found []Mismatch
+	scanner := bufio.NewScanner(bytes.NewReader(goSource))
+	for line := 1; scanner.Scan(); line++ {
+		code, comment, ok := strings.Cut(scanner.Text(), "//")
+		m := use.FindStringSubmatch(code)
+		if !ok || m == nil || names[m[1]] == "" {
+			continue
+		}
+		name := names[m[1]]
+		pct, err := alphaPercent(colors[name])
+		if err != nil {
+			continue
+		}
+		for _, stated := range statedOpacities(comment) {
+			if stated != pct {
+				found = append(found, Mismatch{Line: line, Name: name,
+					Message: fmt.Sprintf("comment says %d%% opacity but the color is %d%% opaque", stated, pct)})
+			}
+		}
+	}
+	return found
+}
+
+// CheckRampOrder checks that the numbered steps of every @ramp family get
+// lighter as the number drops (gray900 darkest, gray50 lightest) and that
+// the @ramp comment lists them in that order
+func CheckRampOrder(colorsCSS []byte) ([]Mismatch, error) {
+	ramps, err := csscolors.LoadRamps(colorsCSS)
+	if err != nil {
+		return nil, err
+	}
+	colors, err := csscolors.LoadColorList(colorsCSS)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []Mismatch
+	for _, r := range ramps {
+		type step struct {
+			name string
+			n    int
+			l    float64
+		}
+		var steps []step
+		for _, c := range colors {
+			m := stepName.FindStringSubmatch(c.Name)
+			if m == nil || m[1] != r.Name {
+				continue
+			}
+			parsed, err := colormath.ParseHex(c.Value)
+			if err != nil || !parsed.IsOpaque() {
+				continue
+			}
+			n, _ := strconv.Atoi(m[2])
+			steps = append(steps, step{c.Name, n, parsed.OKLCH().L})
+		}
+		sort.Slice(steps, func(i, j int) bool { return steps[i].n > steps[j].n })
+
+		for i := 1; i < len(steps); i++ {
+			if prev, s := steps[i-1], steps[i]; s.l <= prev.l {
+				found = append(found, Mismatch{Name: s.name,
+					Message: fmt.Sprintf("L %.3f is not lighter than --%s (L %.3f)", s.l, prev.name, prev.l)})
+			}
+		}
+
+		declared := make([]string, 0, len(steps))
+		for _, s := range steps {
+			if r.Index(s.name) >= 0 {
+				declared = append(declared, s.name)
+			}
+		}
+		if strings.Join(declared, " ") != strings.Join(r.Steps, " ") {
+			found = append(found, Mismatch{Name: r.Name,
+				Message: fmt.Sprintf("@ramp should list its steps darkest first by number: %s", strings.Join(declared, " "))})
+		}
+	}
+	return found, nil
+}
+
+// ValidateColorNames reports colors.css names and comments that disagree
+// with their values. See CheckColorNames.
+func ValidateColorNames(t *testing.T, colorsCSS []byte) {
+	t.Helper()
+
+	found, err := CheckColorNames(colorsCSS)
+	if err != nil {
+		t.Fatalf("Failed to load colors: %v", err)
+	}
+	for _, m := range found {
+		t.Errorf("colors.css %s", m)
+	}
+}
+
+// ValidateOpacityComments reports palette.go comments stating an opacity
+// the color they describe doesn't have
+func ValidateOpacityComments(t *testing.T, colorsCSS []byte, paletteGoContent []byte) {
+	t.Helper()
+
+	colors, err := csscolors.LoadColors(colorsCSS)
+	if err != nil {
+		t.Fatalf("Failed to load colors: %v", err)
+	}
+	for _, m := range CheckOpacityComments(paletteGoContent, colors) {
+		t.Errorf("palette.go %s", m)
+	}
+}
+
+// ValidateRampOrder reports ramp steps whose names are out of lightness
+// order. See CheckRampOrder.
+func ValidateRampOrder(t *testing.T, colorsCSS []byte) {
+	t.Helper()
+
+	found, err := CheckRampOrder(colorsCSS)
+	if err != nil {
+		t.Fatalf("Failed to load ramps: %v", err)
+	}
+	for _, m := range found {
+		t.Errorf("colors.css %s", m)
+	}
+}
@@ -0,0 +1,88 @@
+package colorvalidation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+)
+
+// wantMismatches checks that found holds exactly one mismatch per entry of
+// want, keyed by variable name, whose message contains the given text
+func wantMismatches(t *testing.T, found []Mismatch, want map[string]string) {
+	t.Helper()
+	got := map[string][]string{}
+	for _, m := range found {
+		got[m.Name] = append(got[m.Name], m.Message)
+	}
+	for name, text := range want {
+		if len(got[name]) != 1 || !strings.Contains(got[name][0], text) {
+			t.Errorf("--%s: got %q, want one mismatch containing %q", name, got[name], text)
+		}
+		delete(got, name)
+	}
+	for name, msgs := range got {
+		t.Errorf("--%s: unexpected %q", name, msgs)
+	}
+}
+
+func TestCheckColorNames(t *testing.T) {
+	css := []byte(`:root {
+  --white: #fff;
+  --blue200: #6ee2ffff;
+  --blue200Alpha40: #6ee2ff66; /* Selection with 40% opacity */
+  --whiteAlpha50: #ffffff80;
+  --blueAlpha: #6ee2ff33;
+  --blue200Alpha20: #ffffff33;
+  --blue200Alpha30: #6ee2ff1a;
+  --glow: #6ee2ff40; /* Glow with 30% opacity */
+  --blue200: #6ee2ffff;
+  --dim: #abc8;
+}
+`)
+	found, err := CheckColorNames(css)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantMismatches(t, found, map[string]string{
+		"blueAlpha":      "call it blue200Alpha20",
+		"blue200Alpha20": "its color is #ffffff; call it whiteAlpha20",
+		"blue200Alpha30": "named 30% but #6ee2ff1a is 10% opaque",
+		"glow":           "comment says 30% opacity but #6ee2ff40 is 25% opaque",
+		"blue200":        "declared again (first on line 3)",
+	})
+}
+
+func TestCheckOpacityComments(t *testing.T) {
+	colors := csscolors.ColorMap{"gray900Frosted": "#14191fcc", "gray200": "#e0e6ecff"}
+	src := []byte(`package dark
+
+func GetFrostedPalette() TronThemePalette {
+	p.Background = colors.Gray900Frosted() // 80% opacity
+	p.Surface = colors.Gray900Frosted()    // 50% opacity
+	p.Text = colors.Gray200()              // Full opacity
+}
+`)
+	wantMismatches(t, CheckOpacityComments(src, colors), map[string]string{
+		"gray900Frosted": "comment says 50% opacity but the color is 80% opaque",
+	})
+}
+
+func TestCheckRampOrder(t *testing.T) {
+	css := []byte(`:root {
+  /* @ramp gray: gray900 gray700 gray800 gray50 */
+  --gray900: #101010ff;
+  --gray800: #404040ff;
+  --gray700: #303030ff;
+  --gray50: #fff;
+}
+`)
+	found, err := CheckRampOrder(css)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantMismatches(t, found, map[string]string{
+		"gray700": "is not lighter than --gray800",
+		"gray":    "gray900 gray800 gray700 gray50",
+	})
+}
@@ -1,10 +1,15 @@
 package colorvalidation
 
 import (
+	"bytes"
+	"fmt"
+	"sort"
 	"strings"
 	"testing"
 
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
 	"github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
 )
 
 // ValidateAllColorsUsed checks that all colors defined in the CSS are used in the palette.go file.
@@ -13,24 +18,21 @@ func ValidateAllColorsUsed(t *testing.T, colorsCSS []byte, paletteGoContent []by
 	t.Helper()
 
 	// Load colors from CSS
-	colors, err := csscolors.LoadColors(colorsCSS)
+	colors, err := csscolors.LoadColorList(colorsCSS)
 	if err != nil {
 		t.Fatalf("Failed to load colors: %v", err)
 	}
 
-	// Convert palette.go file content to string for searching
-	paletteContent := string(paletteGoContent)
+	used, err := UsedColors(paletteGoContent, colors)
+	if err != nil {
+		t.Fatalf("Failed to parse palette.go: %v", err)
+	}
 
 	// Track unused colors
 	unusedColors := []string{}
-
-	// Check each color from CSS
-	for colorName := range colors {
-		// Check if the color name appears in palette.go
-		// We check for MustGet("colorName") pattern
-		searchPattern := `MustGet("` + colorName + `")`
-		if !strings.Contains(paletteContent, searchPattern) {
-			unusedColors = append(unusedColors, colorName)
+	for _, c := range colors {
+		if !used[c.Name] {
+			unusedColors = append(unusedColors, c.Name)
 		}
 	}
 
@@ -43,6 +45,21 @@ func ValidateAllColorsUsed(t *testing.T, colorsCSS []byte, paletteGoContent []by
 	}
 }
 
+// UsedColors returns the colors.css variables whose generated accessor
+// (colors.Gray900()) is called in a Go file. Commented-out code doesn't
+// count.
+func UsedColors(goSource []byte, colors []csscolors.NamedColor) (map[string]bool, error) {
+	usages, err := csscolors.Usages(goSource, colors)
+	if err != nil {
+		return nil, err
+	}
+	used := make(map[string]bool, len(usages))
+	for name := range usages {
+		used[name] = true
+	}
+	return used, nil
+}
+
 // ValidateNoDuplicateColorValues checks that each color variable has a unique color value.
 // It reports any duplicate color values as test errors.
 func ValidateNoDuplicateColorValues(t *testing.T, colorsCSS []byte) {
@@ -81,3 +98,161 @@ func ValidateNoDuplicateColorValues(t *testing.T, colorsCSS []byte) {
 		t.Error("Found duplicate color values. Each color variable should have a unique value.")
 	}
 }
+
+// DefaultNearDuplicateDeltaE is the CIEDE2000 difference below which two
+// opaque colors are reported as near-duplicates. A ΔE of 1 is roughly the
+// smallest difference people notice side by side.
+const DefaultNearDuplicateDeltaE = 1.0
+
+// NearDuplicate is a pair of colors.css variables that look the same
+type NearDuplicate struct {
+	A, B   csscolors.NamedColor
+	DeltaE float64
+}
+
+// Suggestion proposes merging the later variable into the earlier one
+func (d NearDuplicate) Suggestion() string {
+	return fmt.Sprintf("--%s (%s) and --%s (%s) are ΔE %.2f apart; merge --%s into --%s",
+		d.A.Name, d.A.Value, d.B.Name, d.B.Value, d.DeltaE, d.B.Name, d.A.Name)
+}
+
+// In reports whether the pair is listed in pairs, in either order
+func (d NearDuplicate) In(pairs [][2]string) bool {
+	for _, pair := range pairs {
+		if (pair[0] == d.A.Name && pair[1] == d.B.Name) || (pair[0] == d.B.Name && pair[1] == d.A.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindNearDuplicates compares every pair of opaque colors with CIEDE2000 and
+// returns those closer than threshold, most similar first. Identical values
+// are left to ValidateNoDuplicateColorValues; translucent colors are skipped
+// since their appearance depends on what they are drawn over.
+func FindNearDuplicates(colors []csscolors.NamedColor, threshold float64) []NearDuplicate {
+	type opaque struct {
+		csscolors.NamedColor
+		c colormath.Color
+	}
+	var candidates []opaque
+	for _, nc := range colors {
+		c, err := colormath.ParseHex(nc.Value)
+		if err != nil || !c.IsOpaque() {
+			continue
+		}
+		candidates = append(candidates, opaque{nc, c})
+	}
+
+	var found []NearDuplicate
+	for i, a := range candidates {
+		for _, b := range candidates[i+1:] {
+			if strings.EqualFold(a.Value, b.Value) {
+				continue
+			}
+			if d := colormath.DeltaE(a.c, b.c); d < threshold {
+				found = append(found, NearDuplicate{A: a.NamedColor, B: b.NamedColor, DeltaE: d})
+			}
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].DeltaE < found[j].DeltaE })
+	return found
+}
+
+// ValidateNoNearDuplicateColors checks that no two opaque colors in the CSS
+// are perceptually indistinguishable, suggesting a merge for each pair found.
+// Pairs listed in allowed are kept apart on purpose; their suggestion is
+// logged instead.
+func ValidateNoNearDuplicateColors(t *testing.T, colorsCSS []byte, threshold float64, allowed ...[2]string) {
+	t.Helper()
+
+	colors, err := csscolors.LoadColorList(colorsCSS)
+	if err != nil {
+		t.Fatalf("Failed to load colors: %v", err)
+	}
+
+	for _, d := range FindNearDuplicates(colors, threshold) {
+		if d.In(allowed) {
+			t.Logf("Allowed near-duplicate colors: %s", d.Suggestion())
+			continue
+		}
+		t.Errorf("Near-duplicate colors: %s", d.Suggestion())
+	}
+}
+
+// ValidateSyntaxRoles checks that syntax roles sharing a color (or colors
+// closer than threshold) are listed together in palette.SyntaxAliases, and
+// that every listed alias still shares a single color. Roles drifting apart
+// by accident or an alias that has since been separated both fail.
+func ValidateSyntaxRoles(t *testing.T, p palette.TronThemePalette, threshold float64) {
+	t.Helper()
+
+	alias := map[string]int{}
+	for i, group := range palette.SyntaxAliases {
+		for _, field := range group {
+			alias[field] = i
+		}
+
+		first, _ := p.Get(group[0])
+		for _, field := range group[1:] {
+			if value, _ := p.Get(field); !strings.EqualFold(value, first) {
+				t.Errorf("Syntax roles %s and %s are aliased but differ (%s vs %s); remove the alias if they were separated on purpose",
+					group[0], field, first, value)
+			}
+		}
+	}
+
+	var roles []string
+	for _, section := range palette.Sections() {
+		if section.Group == "Syntax Highlighting" {
+			roles = append(roles, section.Fields...)
+		}
+	}
+	for i, a := range roles {
+		for _, b := range roles[i+1:] {
+			if ga, ok := alias[a]; ok {
+				if gb, ok := alias[b]; ok && ga == gb {
+					continue
+				}
+			}
+			va, _ := p.Get(a)
+			vb, _ := p.Get(b)
+			ca, errA := colormath.ParseHex(va)
+			cb, errB := colormath.ParseHex(vb)
+			if errA != nil || errB != nil {
+				continue
+			}
+			if d := colormath.DeltaE(ca, cb); d < threshold {
+				t.Errorf("Syntax roles %s (%s) and %s (%s) are ΔE %.2f apart; separate them or add them to palette.SyntaxAliases",
+					a, va, b, vb, d)
+			}
+		}
+	}
+}
+
+// ValidateAccessorsUpToDate checks that the generated colors_gen.go matches
+// colors.css, so a variable added or removed without running go generate is
+// caught
+func ValidateAccessorsUpToDate(t *testing.T, pkg string, colorsCSS []byte, generated []byte) {
+	t.Helper()
+
+	want, err := csscolors.GenerateAccessors(pkg, colorsCSS)
+	if err != nil {
+		t.Fatalf("Failed to generate accessors: %v", err)
+	}
+	if !bytes.Equal(want, generated) {
+		t.Errorf("colors_gen.go is out of date with colors.css; run go generate ./%s", pkg)
+	}
+}
+
+// ValidateFormatted checks that colors.css is in the canonical form written
+// by `generate-theme.go fmt`: lowercase #rrggbbaa values
+func ValidateFormatted(t *testing.T, colorsCSS []byte) {
+	t.Helper()
+
+	f := csscolors.ParseFile(colorsCSS)
+	f.Format()
+	if !bytes.Equal(f.Bytes(), colorsCSS) {
+		t.Error("colors.css is not formatted; run cd tools && go run generate-theme.go fmt")
+	}
+}
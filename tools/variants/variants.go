@@ -0,0 +1,156 @@
+package variants
+
+import (
+	"log"
+	"strings"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/csscolors"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/cvd"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/dark"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/derive"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/light"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+// All returns every theme variant in the order they appear in themes/tron-legacy.json
+func All() []palette.ThemeVariant {
+	return []palette.ThemeVariant{
+		{
+			Name:       "Tron Legacy",
+			Appearance: "dark",
+			Palette:    dark.GetPalette(),
+		},
+		{
+			Name:       "Tron Legacy Frosted",
+			Appearance: "dark",
+			Palette:    dark.GetFrostedPalette(),
+		},
+		{
+			Name:       "Tron Legacy Light",
+			Appearance: "light",
+			Palette:    light.GetPalette(),
+		},
+		{
+			Name:       "Tron Legacy Light Frosted",
+			Appearance: "light",
+			Palette:    light.GetFrostedPalette(),
+		},
+		{
+			Name:       "Tron Legacy Frosted Lite",
+			Appearance: "dark",
+			Palette:    derive.Frosted(dark.GetPalette(), derive.FrostedLite),
+		},
+		{
+			Name:       "Tron Legacy Frosted Heavy",
+			Appearance: "dark",
+			Palette:    derive.Frosted(dark.GetPalette(), derive.FrostedHeavy),
+		},
+		{
+			Name:       "Tron Legacy Light Frosted Lite",
+			Appearance: "light",
+			Palette:    derive.Frosted(light.GetPalette(), derive.FrostedLite),
+		},
+		{
+			Name:       "Tron Legacy Light Frosted Heavy",
+			Appearance: "light",
+			Palette:    derive.Frosted(light.GetPalette(), derive.FrostedHeavy),
+		},
+		{
+			Name:       "Tron Legacy High Contrast",
+			Appearance: "dark",
+			Palette:    derive.HighContrast(dark.GetPalette(), palette.Desktop("dark")),
+		},
+		{
+			Name:       "Tron Legacy Light High Contrast",
+			Appearance: "light",
+			Palette:    derive.HighContrast(light.GetPalette(), palette.Desktop("light")),
+		},
+		colorblind("Tron Legacy", "dark", dark.GetPalette(), cvd.Protanopia),
+		colorblind("Tron Legacy", "dark", dark.GetPalette(), cvd.Deuteranopia),
+		colorblind("Tron Legacy", "dark", dark.GetPalette(), cvd.Tritanopia),
+		colorblind("Tron Legacy Light", "light", light.GetPalette(), cvd.Protanopia),
+		colorblind("Tron Legacy Light", "light", light.GetPalette(), cvd.Deuteranopia),
+		colorblind("Tron Legacy Light", "light", light.GetPalette(), cvd.Tritanopia),
+		accent("Tron Orange", "dark", dark.GetPalette(), "orange500"),
+		accent("Tron Green", "dark", dark.GetPalette(), "green300"),
+		accent("Tron Red", "dark", dark.GetPalette(), "red400"),
+		accent("Tron Orange Light", "light", light.GetPalette(), "orange500"),
+		accent("Tron Green Light", "light", light.GetPalette(), "green400"),
+		accent("Tron Red Light", "light", light.GetPalette(), "red500"),
+	}
+}
+
+// accent derives a variant whose UI accent is the colors.css variable
+// instead of blue, e.g. Clu's orange for "Tron Orange"
+func accent(name, appearance string, p palette.TronThemePalette, variable string) palette.ThemeVariant {
+	v := palette.ThemeVariant{Name: name, Appearance: appearance}
+	colors, err := csscolors.LoadColors(ColorsCSS(v))
+	if err != nil {
+		log.Fatalf("Failed to load %s colors: %v", appearance, err)
+	}
+	v.Palette = derive.Accent(p, colors.MustGet(variable))
+	return v
+}
+
+// colorblind derives a variant like "Tron Legacy Deuteranopia-friendly"
+func colorblind(base, appearance string, p palette.TronThemePalette, d cvd.Deficiency) palette.ThemeVariant {
+	name := string(d)
+	return palette.ThemeVariant{
+		Name:       base + " " + strings.ToUpper(name[:1]) + name[1:] + "-friendly",
+		Appearance: appearance,
+		Palette:    derive.ColorblindFriendly(p, d),
+	}
+}
+
+// Deficiency returns the color vision deficiency a colorblind-friendly
+// variant is tuned for
+func Deficiency(v palette.ThemeVariant) (cvd.Deficiency, bool) {
+	for _, d := range cvd.Deficiencies {
+		if strings.HasSuffix(v.Slug(), "-"+string(d)+"-friendly") {
+			return d, true
+		}
+	}
+	return "", false
+}
+
+// Find returns the variant matching a display name or slug
+func Find(name string) (palette.ThemeVariant, bool) {
+	for _, v := range All() {
+		if v.Name == name || v.Slug() == name {
+			return v, true
+		}
+	}
+	return palette.ThemeVariant{}, false
+}
+
+// ColorsCSS returns the raw colors.css a variant's palette is built from
+func ColorsCSS(v palette.ThemeVariant) []byte {
+	if v.Appearance == "light" {
+		return light.ColorsCSS()
+	}
+	return dark.ColorsCSS()
+}
+
+// PaletteSource returns the palette.go a variant's palette is defined in
+func PaletteSource(v palette.ThemeVariant) []byte {
+	if v.Appearance == "light" {
+		return light.PaletteSource()
+	}
+	return dark.PaletteSource()
+}
+
+// PaletteFuncs returns the palette.go functions a variant's palette can be
+// built from and the palettes they return, keyed by function name
+func PaletteFuncs(v palette.ThemeVariant) map[string]palette.TronThemePalette {
+	if v.Appearance == "light" {
+		return map[string]palette.TronThemePalette{"GetPalette": light.GetPalette(), "GetFrostedPalette": light.GetFrostedPalette()}
+	}
+	return map[string]palette.TronThemePalette{"GetPalette": dark.GetPalette(), "GetFrostedPalette": dark.GetFrostedPalette()}
+}
+
+// Desktop returns the plain desktop color translucent (frosted) backgrounds
+// are flattened over: black for dark variants, white for light ones.
+func Desktop(v palette.ThemeVariant) colormath.Color {
+	return palette.Desktop(v.Appearance)
+}
@@ -0,0 +1,132 @@
+package variants
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/cvd"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+)
+
+func TestCriticalPairsDistinguishable(t *testing.T) {
+	for _, v := range All() {
+		style := palette.GenerateThemeStyle(v.Name, v.Appearance, v.Palette).Style
+		pairs := cvd.CriticalPairs(style)
+
+		// Colorblind-friendly variants must also keep version control
+		// states apart
+		if _, friendly := Deficiency(v); friendly {
+			pairs = append(pairs, cvd.VersionControlPairs(style)...)
+		}
+
+		results, err := cvd.AuditPairs(style, Desktop(v), pairs)
+		if err != nil {
+			t.Fatalf("%s: %v", v.Name, err)
+		}
+		for _, r := range results {
+			if r.DeltaE >= cvd.MinDeltaE {
+				continue
+			}
+			vision := string(r.Deficiency)
+			if vision == "" {
+				vision = "normal vision"
+			}
+			t.Errorf("%s: %s ΔE %.2f under %s, want >= %.1f", v.Name, r.Pair.Name, r.DeltaE, vision, cvd.MinDeltaE)
+		}
+	}
+}
+
+func TestGeneratedPlayers(t *testing.T) {
+	// Pairwise cursor distance under each dichromacy is covered by
+	// TestCriticalPairsDistinguishable; here every player must be distinct
+	// and readable
+	for _, v := range All() {
+		style := palette.GenerateThemeStyle(v.Name, v.Appearance, v.Palette).Style
+		if len(style.Players) != palette.PlayerCount {
+			t.Errorf("%s: %d players, want %d", v.Name, len(style.Players), palette.PlayerCount)
+		}
+		if _, err := palette.GeneratePlayers(v.Palette, palette.PlayerCount, Desktop(v)); err != nil {
+			t.Errorf("%s: %v", v.Name, err)
+		}
+		bg := colormath.MustParseHex(style.EditorBackground).Over(colormath.MustParseHex(style.Background).Over(Desktop(v)))
+		fg := colormath.MustParseHex(style.EditorForeground).Over(bg)
+		// Text already at AAA keeps AAA on selections
+		target := 4.5
+		if colormath.Contrast(fg, bg) >= 7 {
+			target = 7
+		}
+		seen := map[string]int{}
+		for i, player := range style.Players {
+			cursor := colormath.MustParseHex(player.Cursor)
+			if j, dup := seen[strings.ToLower(player.Cursor)]; dup {
+				t.Errorf("%s: players[%d] repeats the cursor of players[%d]", v.Name, i, j)
+			}
+			seen[strings.ToLower(player.Cursor)] = i
+			if c := colormath.Contrast(cursor.Over(bg), bg); c < 3 {
+				t.Errorf("%s: players[%d].cursor contrast %.2f, want >= 3", v.Name, i, c)
+			}
+			selection := colormath.MustParseHex(player.Selection).Over(bg)
+			if c := colormath.Contrast(fg, selection); c < target {
+				t.Errorf("%s: text on players[%d].selection contrast %.2f, want >= %.1f", v.Name, i, c, target)
+			}
+		}
+	}
+}
+
+func TestStatusTiers(t *testing.T) {
+	for _, v := range All() {
+		if _, err := palette.GenerateStatusTiers(v.Palette, Desktop(v)); err != nil {
+			t.Errorf("%s: %v", v.Name, err)
+		}
+		style := palette.GenerateThemeStyle(v.Name, v.Appearance, v.Palette).Style
+		// Diagnostics render on the editor background
+		bg := colormath.MustParseHex(style.EditorBackground).Over(colormath.MustParseHex(style.Background).Over(Desktop(v)))
+		fg := colormath.MustParseHex(style.Text).Over(bg)
+		for _, tier := range []struct{ name, surface, border string }{
+			{"warning", style.WarningBackground, style.WarningBorder},
+			{"modified", style.ModifiedBackground, style.ModifiedBorder},
+			{"info", style.InfoBackground, style.InfoBorder},
+			{"hint", style.HintBackground, style.HintBorder},
+			{"conflict", style.ConflictBackground, style.ConflictBorder},
+			{"renamed", style.RenamedBackground, style.RenamedBorder},
+		} {
+			surface := colormath.MustParseHex(tier.surface)
+			if surface.A == 0 || strings.EqualFold(tier.surface, style.Background) {
+				t.Errorf("%s: %s.background %s is not tinted", v.Name, tier.name, tier.surface)
+			}
+			flat := surface.Over(bg)
+			if c := colormath.Contrast(fg, flat); c < 4.5 {
+				t.Errorf("%s: text on %s.background contrast %.2f, want >= 4.5", v.Name, tier.name, c)
+			}
+			if c := colormath.Contrast(colormath.MustParseHex(tier.border).Over(flat), flat); c < 3 {
+				t.Errorf("%s: %s.border contrast %.2f, want >= 3", v.Name, tier.name, c)
+			}
+		}
+	}
+}
+
+func TestColorblindVariants(t *testing.T) {
+	for _, v := range All() {
+		d, ok := Deficiency(v)
+		if !ok {
+			continue
+		}
+		base, _ := Find("Tron Legacy")
+		if v.Appearance == "light" {
+			base, _ = Find("Tron Legacy Light")
+		}
+
+		// Only red/green slots move; Tron blue and orange stay put
+		for _, field := range []string{"Info", "Accent", "Keyword", "Function", "BorderFocused", "TerminalBlue"} {
+			got, _ := v.Palette.Get(field)
+			want, _ := base.Palette.Get(field)
+			if got != want {
+				t.Errorf("%s: %s = %s, want %s as in %s", v.Name, field, got, want, base.Name)
+			}
+		}
+		if v.Palette.VCSModified == base.Palette.VCSModified {
+			t.Errorf("%s: VCSModified was not remapped for %s", v.Name, d)
+		}
+	}
+}
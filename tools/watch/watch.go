@@ -0,0 +1,228 @@
+// Package watch rebuilds the theme whenever its sources change and
+// installs the result into Zed's themes directory, which Zed reloads on
+// its own.
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/colormath"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+	"github.com/fsnotify/fsnotify"
+)
+
+// Config describes what to watch, how to rebuild and where to install
+type Config struct {
+	// Dirs are watched non-recursively
+	Dirs []string
+	// Match reports whether a change to path should trigger a rebuild
+	Match func(path string) bool
+	// Debounce is how long to wait for further changes before rebuilding,
+	// since editors often save in several steps
+	Debounce time.Duration
+	// Build returns the theme family JSON. changed holds the files that
+	// triggered the build, empty for the initial one.
+	Build func(changed []string) ([]byte, error)
+	// Install is the file the theme is written to
+	Install string
+	// Log receives progress and errors
+	Log io.Writer
+}
+
+// DefaultThemesDir returns Zed's user themes directory on Linux:
+// $XDG_CONFIG_HOME/zed/themes, or ~/.config/zed/themes
+func DefaultThemesDir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "zed", "themes"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "zed", "themes"), nil
+}
+
+// Run builds and installs the theme once, then again after every matching
+// change until ctx is cancelled. Build, validation, install and file watcher
+// errors are logged and the watcher keeps going; after a watcher error it
+// re-adds the watches and rebuilds in case events were lost. Only a failure
+// to set up the watches returns an error.
+func Run(ctx context.Context, cfg Config) error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	for _, dir := range cfg.Dirs {
+		if err := w.Add(dir); err != nil {
+			return fmt.Errorf("watching %s: %w", dir, err)
+		}
+	}
+	return loop(ctx, cfg, w.Events, w.Errors, w.Add)
+}
+
+// loop is Run once the watches are set up. add re-adds a watched directory
+// after a watcher error.
+func loop(ctx context.Context, cfg Config, events <-chan fsnotify.Event, errs <-chan error, add func(dir string) error) error {
+	var installed []byte
+	rebuild := func(changed []string) {
+		start := time.Now()
+		data, err := cfg.Build(changed)
+		if err == nil {
+			err = Validate(data)
+		}
+		if err != nil {
+			fmt.Fprintf(cfg.Log, "Error: %v\n", err)
+			return
+		}
+		if bytes.Equal(data, installed) {
+			fmt.Fprintf(cfg.Log, "No changes to %s (%s)\n", cfg.Install, time.Since(start).Round(time.Millisecond))
+			return
+		}
+		if err := WriteFileAtomic(cfg.Install, data, 0644); err != nil {
+			fmt.Fprintf(cfg.Log, "Error: %v\n", err)
+			return
+		}
+		installed = data
+		fmt.Fprintf(cfg.Log, "Installed %s (%s)\n", cfg.Install, time.Since(start).Round(time.Millisecond))
+	}
+	rebuild(nil)
+
+	pending := map[string]bool{}
+	timer := time.NewTimer(0)
+	<-timer.C
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if ev.Op == fsnotify.Chmod || !cfg.Match(ev.Name) {
+				continue
+			}
+			pending[ev.Name] = true
+			timer.Reset(cfg.Debounce)
+		case err, ok := <-errs:
+			if !ok {
+				return nil
+			}
+			// Events may have been dropped (e.g. a queue overflow), so
+			// re-add the watches and rebuild rather than trust the tree
+			fmt.Fprintf(cfg.Log, "Error: watching: %v\n", err)
+			for _, dir := range cfg.Dirs {
+				if err := add(dir); err != nil {
+					fmt.Fprintf(cfg.Log, "Error: watching %s: %v\n", dir, err)
+				}
+			}
+			timer.Reset(cfg.Debounce)
+		case <-timer.C:
+			changed := make([]string, 0, len(pending))
+			for name := range pending {
+				changed = append(changed, name)
+			}
+			sort.Strings(changed)
+			pending = map[string]bool{}
+			if len(changed) > 0 {
+				fmt.Fprintf(cfg.Log, "Changed: %s\n", strings.Join(changed, ", "))
+			}
+			rebuild(changed)
+		}
+	}
+}
+
+// Validate checks that data is a theme family Zed can load: it decodes
+// into palette.Theme without unknown keys, every theme has a name and an
+// appearance, and every color parses.
+func Validate(data []byte) error {
+	var family palette.Theme
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&family); err != nil {
+		return fmt.Errorf("invalid theme JSON: %w", err)
+	}
+	if len(family.Themes) == 0 {
+		return fmt.Errorf("theme family %q has no themes", family.Name)
+	}
+	for i, t := range family.Themes {
+		if t.Name == "" {
+			return fmt.Errorf("theme %d has no name", i)
+		}
+		if t.Appearance != "dark" && t.Appearance != "light" {
+			return fmt.Errorf("%s: appearance %q is neither dark nor light", t.Name, t.Appearance)
+		}
+		if t.Style == nil {
+			return fmt.Errorf("%s: no style", t.Name)
+		}
+	}
+
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	return checkColors(doc, "")
+}
+
+// checkColors parses every string in v that looks like a color
+func checkColors(v any, path string) error {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if err := checkColors(child, path+"/"+k); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for i, child := range v {
+			if err := checkColors(child, fmt.Sprintf("%s/%d", path, i)); err != nil {
+				return err
+			}
+		}
+	case string:
+		if strings.HasPrefix(v, "#") {
+			if _, err := colormath.ParseHex(v); err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// WriteFileAtomic writes data to a temporary file next to path and renames
+// it into place, so a reader such as Zed never sees a half-written file
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,218 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/palette"
+	"github.com/bcomnes/zed-theme-tron-legacy/tools/variants"
+	"github.com/fsnotify/fsnotify"
+)
+
+func theme(t *testing.T) []byte {
+	t.Helper()
+	data, err := json.MarshalIndent(palette.GenerateTheme("Tron Legacy", "Bret Comnes", variants.All()...), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestValidate(t *testing.T) {
+	data := theme(t)
+	if err := Validate(data); err != nil {
+		t.Fatalf("generated theme: %v", err)
+	}
+
+	broken := map[string][]byte{
+		"bad color":      bytes.Replace(data, []byte(`"#14191fff"`), []byte(`"#zz191fff"`), 1),
+		"unknown key":    bytes.Replace(data, []byte(`"border":`), []byte(`"bordr":`), 1),
+		"no themes":      []byte(`{"name": "Tron Legacy", "themes": []}`),
+		"bad appearance": bytes.Replace(data, []byte(`"appearance": "dark"`), []byte(`"appearance": "dim"`), 1),
+		"truncated":      data[:len(data)/2],
+	}
+	for name, data := range broken {
+		if err := Validate(data); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "themes", "tron-legacy.json")
+	for _, content := range []string{"first", "second"} {
+		if err := WriteFileAtomic(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := os.ReadFile(path); string(got) != content {
+			t.Errorf("got %q, want %q", got, content)
+		}
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("temporary files left behind: %v", entries)
+	}
+}
+
+// syncLog is an io.Writer safe to read while Run writes to it
+type syncLog struct {
+	mu sync.Mutex
+	b  strings.Builder
+}
+
+func (l *syncLog) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.b.Write(p)
+}
+
+func (l *syncLog) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.b.String()
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRun(t *testing.T) {
+	src := t.TempDir()
+	install := filepath.Join(t.TempDir(), "tron-legacy.json")
+	valid := theme(t)
+
+	// The fake build fails while src/colors.css says "broken"
+	var mu sync.Mutex
+	var builds [][]string
+	build := func(changed []string) ([]byte, error) {
+		mu.Lock()
+		builds = append(builds, changed)
+		mu.Unlock()
+		if css, _ := os.ReadFile(filepath.Join(src, "colors.css")); string(css) == "broken" {
+			return nil, errors.New("colors.css is broken")
+		}
+		return valid, nil
+	}
+	buildCount := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(builds)
+	}
+
+	log := &syncLog{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- Run(ctx, Config{
+			Dirs:     []string{src},
+			Match:    func(path string) bool { return strings.HasSuffix(path, ".css") },
+			Debounce: 20 * time.Millisecond,
+			Build:    build,
+			Install:  install,
+			Log:      log,
+		})
+	}()
+
+	waitFor(t, "the initial install", func() bool {
+		got, _ := os.ReadFile(install)
+		return bytes.Equal(got, valid)
+	})
+
+	// Unmatched files are ignored; a burst of writes is one build
+	os.WriteFile(filepath.Join(src, "notes.txt"), []byte("x"), 0644)
+	for _, content := range []string{"a", "b", "broken"} {
+		os.WriteFile(filepath.Join(src, "colors.css"), []byte(content), 0644)
+	}
+	waitFor(t, "the build error", func() bool { return strings.Contains(log.String(), "colors.css is broken") })
+	if n := buildCount(); n != 2 {
+		t.Errorf("%d builds, want 2", n)
+	}
+
+	// The watcher survives the error and picks up the fix
+	os.WriteFile(filepath.Join(src, "colors.css"), []byte("fixed"), 0644)
+	waitFor(t, "the rebuild after the fix", func() bool { return buildCount() == 3 })
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(builds[0]) != 0 || len(builds[1]) != 1 || filepath.Base(builds[1][0]) != "colors.css" {
+		t.Errorf("builds were passed %v", builds)
+	}
+}
+
+func TestRunSurvivesWatcherErrors(t *testing.T) {
+	valid := theme(t)
+	var mu sync.Mutex
+	builds := 0
+	build := func(changed []string) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		builds++
+		return valid, nil
+	}
+	buildCount := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return builds
+	}
+
+	log := &syncLog{}
+	events := make(chan fsnotify.Event)
+	errs := make(chan error)
+	var readded []string
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- loop(ctx, Config{
+			Dirs:     []string{"src"},
+			Match:    func(string) bool { return true },
+			Debounce: 10 * time.Millisecond,
+			Build:    build,
+			Install:  filepath.Join(t.TempDir(), "tron-legacy.json"),
+			Log:      log,
+		}, events, errs, func(dir string) error {
+			readded = append(readded, dir)
+			return nil
+		})
+	}()
+	waitFor(t, "the initial build", func() bool { return buildCount() == 1 })
+
+	// An overflow is logged, the watches come back and the theme is
+	// rebuilt in case a change was dropped
+	errs <- fsnotify.ErrEventOverflow
+	waitFor(t, "the rebuild after the overflow", func() bool { return buildCount() == 2 })
+	if !strings.Contains(log.String(), fsnotify.ErrEventOverflow.Error()) {
+		t.Errorf("overflow not logged:\n%s", log.String())
+	}
+
+	// The watcher still handles events
+	events <- fsnotify.Event{Name: "src/colors.css", Op: fsnotify.Write}
+	waitFor(t, "the rebuild after the change", func() bool { return buildCount() == 3 })
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if len(readded) != 1 || readded[0] != "src" {
+		t.Errorf("re-added %v, want [src]", readded)
+	}
+}